@@ -1,10 +1,15 @@
 package redisearch
 
-import "strconv"
+import (
+	"strconv"
+	"time"
+)
 
 type RedisSearchAggregation struct {
-	query *RedisSearchQuery
-	args  []interface{}
+	query         *RedisSearchQuery
+	args          []interface{}
+	cursorCount   int
+	cursorMaxIdle time.Duration
 }
 
 type RedisSearchAggregationSort struct {
@@ -12,6 +17,26 @@ type RedisSearchAggregationSort struct {
 	Desc  bool
 }
 
+func (a *RedisSearchAggregation) compile(index string) ([]interface{}, error) {
+	if a.query == nil {
+		a.query = NewRedisSearchQuery()
+	}
+
+	args, err := a.query.buildQueryArgs([]interface{}{"FT.AGGREGATE", index}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(args, a.args...), nil
+}
+
+func (a *RedisSearchAggregation) WithCursor(count int, maxIdle time.Duration) *RedisSearchAggregation {
+	a.cursorCount = count
+	a.cursorMaxIdle = maxIdle
+
+	return a
+}
+
 func (a *RedisSearchAggregation) GroupByField(field string, reduce ...AggregateReduce) *RedisSearchAggregation {
 	return a.GroupByFields([]string{field}, reduce...)
 }
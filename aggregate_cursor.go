@@ -0,0 +1,168 @@
+package redisearch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/latolukasz/beeorm/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+type RedisSearchCursor struct {
+	engine  *RedisSearchEngine
+	index   string
+	count   int
+	id      int64
+	pending []map[string]string
+	started bool
+}
+
+func (r *RedisSearchEngine) AggregateCursor(index string, query *RedisSearchAggregation) *RedisSearchCursor {
+	cursor, err := r.AggregateCursorE(index, query)
+	checkError(err)
+
+	return cursor
+}
+
+func (r *RedisSearchEngine) AggregateCursorE(index string, query *RedisSearchAggregation) (*RedisSearchCursor, error) {
+	index = r.redis.AddNamespacePrefix(index)
+
+	args, err := query.compile(index)
+	if err != nil {
+		return nil, err
+	}
+
+	args = append(args, "WITHCURSOR")
+
+	if query.cursorCount > 0 {
+		args = append(args, "COUNT", query.cursorCount)
+	}
+
+	if query.cursorMaxIdle > 0 {
+		args = append(args, "MAXIDLE", query.cursorMaxIdle.Milliseconds())
+	}
+
+	cursor := &RedisSearchCursor{engine: r, index: index, count: query.cursorCount}
+
+	rows, err := cursor.execute("FT.AGGREGATE", args)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor.pending = rows
+
+	return cursor, nil
+}
+
+func (r *RedisSearchEngine) RedisSearchAggregateCursor(entity beeorm.Entity, query *RedisSearchAggregation) *RedisSearchCursor {
+	cursor, err := r.RedisSearchAggregateCursorE(entity, query)
+	checkError(err)
+
+	return cursor
+}
+
+func (r *RedisSearchEngine) RedisSearchAggregateCursorE(entity beeorm.Entity, query *RedisSearchAggregation) (*RedisSearchCursor, error) {
+	schema := r.engine.GetRegistry().GetEntitySchemaForEntity(entity)
+
+	redisSearchSchema, err := getRedisSearchSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.query == nil {
+		query.query = NewRedisSearchQuery()
+	}
+
+	if redisSearchSchema.hasSearchableFakeDelete {
+		query.query.hasFakeDelete = true
+	}
+
+	return r.AggregateCursorE(redisSearchSchema.index.Name, query)
+}
+
+// Next returns next batch of rows, false is returned when cursor is exhausted
+func (c *RedisSearchCursor) Next() (rows []map[string]string, has bool) {
+	rows, has, err := c.NextE()
+	checkError(err)
+
+	return rows, has
+}
+
+func (c *RedisSearchCursor) NextE() (rows []map[string]string, has bool, err error) {
+	if !c.started {
+		c.started = true
+
+		return c.pending, true, nil
+	}
+
+	c.pending = nil
+
+	if c.id == 0 {
+		return nil, false, nil
+	}
+
+	args := []interface{}{"FT.CURSOR", "READ", c.index, c.id}
+
+	if c.count > 0 {
+		args = append(args, "COUNT", c.count)
+	}
+
+	rows, err = c.execute("FT.CURSOR READ", args)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return rows, true, nil
+}
+
+// Close deletes cursor in Redis if it was not exhausted yet
+func (c *RedisSearchCursor) Close() {
+	checkError(c.CloseE())
+}
+
+func (c *RedisSearchCursor) CloseE() error {
+	if c.id == 0 {
+		return nil
+	}
+
+	cmd := redis.NewStatusCmd(c.engine.ctx, "FT.CURSOR", "DEL", c.index, c.id)
+	c.id = 0
+
+	err := c.engine.process(cmd, "FT.CURSOR DEL", "")
+	if err != nil && strings.Contains(strings.ToLower(err.Error()), "cursor does not exist") {
+		return nil
+	}
+
+	return err
+}
+
+func (c *RedisSearchCursor) execute(operation string, args []interface{}) ([]map[string]string, error) {
+	cmd := redis.NewSliceCmd(c.engine.ctx, args...)
+
+	if err := c.engine.process(cmd, operation, ""); err != nil {
+		return nil, err
+	}
+
+	res, err := cmd.Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 2 {
+		return nil, fmt.Errorf("%w: cursor reply with %d elements", ErrUnexpectedReply, len(res))
+	}
+
+	id, ok := res[1].(int64)
+	if !ok {
+		return nil, fmt.Errorf("%w: cursor id is not a number", ErrUnexpectedReply)
+	}
+
+	c.id = id
+
+	rows, ok := res[0].([]interface{})
+	if !ok || len(rows) == 0 {
+		return nil, fmt.Errorf("%w: cursor rows are not an array", ErrUnexpectedReply)
+	}
+
+	return parseAggregateRows(rows[1:]), nil
+}
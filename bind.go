@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -21,8 +22,9 @@ type tableSchemaRedisSearch struct {
 	mapPointerToValue       mapPointerToValue
 	columnMapping           map[string]int
 	redisSearchPrefix       string
-	redisSearchPrefixLen    int
 	searchCacheName         string
+	suggestFields           map[string]redisSearchSuggestField
+	geoFields               map[string]redisSearchGeoField
 	hasFakeDelete           bool
 	hasSearchableFakeDelete bool
 }
@@ -31,7 +33,13 @@ type mapBindToRedisSearch map[string]func(val interface{}) interface{}
 type mapBindToScanPointer map[string]func() interface{}
 type mapPointerToValue map[string]func(val interface{}) interface{}
 
-func (tableSchema *tableSchemaRedisSearch) fillRedisSearchFromBind(redisSetter beeorm.RedisCacheSetter, bind beeorm.Bind, id uint64, insert bool) {
+func (tableSchema *tableSchemaRedisSearch) fillRedisSearchFromBind(
+	redisSetter beeorm.RedisCacheSetter,
+	bind beeorm.Bind,
+	id uint64,
+	insert bool,
+	keys []string,
+) (removedFields []string) {
 	delete(bind, "ID")
 
 	values := make([]interface{}, 0)
@@ -42,7 +50,7 @@ func (tableSchema *tableSchemaRedisSearch) fillRedisSearchFromBind(redisSetter b
 
 		if has && val != "0" {
 			if !tableSchema.hasSearchableFakeDelete {
-				redisSetter.Del(tableSchema.searchCacheName, tableSchema.redisSearchPrefix+strconv.FormatUint(id, 10))
+				redisSetter.Del(keys...)
 			} else {
 				values = append(values, "FakeDelete", "true")
 				hasChangedField = true
@@ -63,14 +71,84 @@ func (tableSchema *tableSchemaRedisSearch) fillRedisSearchFromBind(redisSetter b
 	for k, f := range tableSchema.mapBindToRedisSearch {
 		v, has := bind[k]
 		if has {
-			values = append(values, k, f(v))
+			mapped := f(v)
+			if mapped == nil {
+				removedFields = append(removedFields, k)
+
+				continue
+			}
+
+			values = append(values, k, mapped)
 			hasChangedField = true
 		}
 	}
 
+	for _, name := range sortedKeys(tableSchema.geoFields) {
+		lon, hasLon := bind[tableSchema.geoFields[name].lon]
+		lat, hasLat := bind[tableSchema.geoFields[name].lat]
+
+		if !hasLon && !hasLat {
+			continue
+		}
+
+		mapped := geoValue(lon, lat)
+		if mapped == nil {
+			removedFields = append(removedFields, name)
+
+			continue
+		}
+
+		values = append(values, name, mapped)
+		hasChangedField = true
+	}
+
 	if hasChangedField {
-		redisSetter.HSet(tableSchema.redisSearchPrefix+strconv.FormatUint(id, 10), values...)
+		for _, key := range keys {
+			redisSetter.HSet(key, values...)
+		}
 	}
+
+	return removedFields
+}
+
+// rewriteDocument replaces whole document with the row loaded from MySQL, so removed fields
+// are dropped by the same setter which writes the new values
+func (tableSchema *tableSchemaRedisSearch) rewriteDocument(
+	engine beeorm.Engine,
+	entitySchema beeorm.EntitySchema,
+	redisSetter beeorm.RedisCacheSetter,
+	id uint64,
+	keys []string,
+) {
+	columns := sortedKeys(tableSchema.mapBindToRedisSearch)
+	geoFields := sortedKeys(tableSchema.geoFields)
+	query := tableSchema.indexerQuery(entitySchema.GetTableName(), append(append([]string{}, columns...), tableSchema.geoColumns(geoFields)...), 0, 1)
+
+	redisSetter.Del(keys...)
+
+	documents := tableSchema.loadDocuments(engine.GetMysql(entitySchema.GetMysqlPool()), query, columns, geoFields, id-1)
+	if len(documents) == 0 || documents[0].id != id {
+		return
+	}
+
+	values := make([]interface{}, 0, len(documents[0].fields)*2)
+
+	for _, field := range sortedKeys(documents[0].fields) {
+		values = append(values, field, documents[0].fields[field])
+	}
+
+	if len(values) == 0 {
+		return
+	}
+
+	for _, key := range keys {
+		redisSetter.HSet(key, values...)
+	}
+}
+
+// documentID returns ID from the document key, which can contain versioned prefix
+func documentID(key string) (uint64, error) {
+	return strconv.ParseUint(key[strings.LastIndex(key, ":")+1:], 10, 64)
 }
 
 //nolint //Function has too many statements
@@ -102,39 +180,134 @@ func (tableSchema *tableSchemaRedisSearch) buildRedisSearchIndex(tableSchemaBeeO
 		tableSchema.index.Fields[0].NoIndex = false
 	}
 
-	tableSchema.index.StopWords = []string{}
 	tableSchema.index.Name = tableSchemaBeeORM.GetEntityName()
 	tableSchema.index.RedisPool = tableSchema.searchCacheName
 	tableSchema.redisSearchPrefix = fmt.Sprintf("%x", sha256.Sum256([]byte(tableSchemaBeeORM.GetEntityName())))
 	tableSchema.redisSearchPrefix = tableSchema.redisSearchPrefix[0:5] + ":"
-	tableSchema.redisSearchPrefixLen = len(tableSchema.redisSearchPrefix)
 	tableSchema.index.Prefixes = []string{tableSchema.redisSearchPrefix}
-	tableSchema.index.NoOffsets = true
-	tableSchema.index.NoFreqs = true
-	tableSchema.index.NoNHL = true
 	tableSchema.index.SkipInitialScan = true
 
-	indexQuery := "SELECT `ID`"
+	if err := tableSchema.applyIndexOptions(tableSchemaBeeORM); err != nil {
+		return err
+	}
+	tableSchema.index.BlueGreen = tableSchemaBeeORM.GetTag("ORM", "redisSearchBlueGreen", "true", "") == "true"
+
 	indexColumns := make([]string, 0)
 
 	for column := range tableSchema.mapBindToRedisSearch {
-		indexQuery += ",`" + column + "`"
 		indexColumns = append(indexColumns, column)
 	}
 
-	indexQuery += " FROM `" + tableSchemaBeeORM.GetTableName() + "` WHERE `ID` > ?"
+	mysqlPool := tableSchemaBeeORM.GetMysqlPool()
+	tableName := tableSchemaBeeORM.GetTableName()
+
+	tableSchema.index.Indexer = tableSchema.buildIndexer(mysqlPool, tableName, indexColumns, tableSchema.suggestColumns(), sortedKeys(tableSchema.geoFields), 0)
+	tableSchema.index.rangeIndexer = func(to uint64) RedisSearchIndexerFunc {
+		return tableSchema.buildIndexer(mysqlPool, tableName, indexColumns, tableSchema.suggestColumns(), sortedKeys(tableSchema.geoFields), to)
+	}
+	tableSchema.index.dictionaries = tableSchema.suggestKeys()
+	tableSchema.index.fieldsIndexer = func(fields []string) RedisSearchIndexerFunc {
+		columns := make([]string, 0, len(fields))
+		geoFields := make([]string, 0)
+
+		for _, field := range fields {
+			if _, isGeo := tableSchema.geoFields[field]; isGeo {
+				geoFields = append(geoFields, field)
+			} else {
+				columns = append(columns, field)
+			}
+		}
+
+		return tableSchema.buildIndexer(mysqlPool, tableName, columns, nil, geoFields, 0)
+	}
+	tableSchema.index.MaxID = func(engine beeorm.Engine) uint64 {
+		maxID := uint64(0)
+		engine.GetMysql(mysqlPool).QueryRow(beeorm.NewWhere("SELECT IFNULL(MAX(`ID`), 0) FROM `"+tableName+"`"), &maxID)
+
+		return maxID
+	}
+
+	return nil
+}
+
+func (tableSchema *tableSchemaRedisSearch) applyIndexOptions(tableSchemaBeeORM beeorm.SettableEntitySchema) error {
+	index := tableSchema.index
+	index.NoOffsets = true
+	index.NoFreqs = true
+	index.NoNHL = true
+	index.StopWords = []string{}
+
+	if options := tableSchemaBeeORM.GetTag("ORM", "redisSearchOptions", "", ""); options != "" {
+		for _, option := range strings.Split(options, ",") {
+			switch strings.TrimSpace(option) {
+			case "offsets":
+				index.NoOffsets = false
+			case "highlight":
+				index.NoOffsets = false
+				index.NoNHL = false
+			case "freqs":
+				index.NoFreqs = false
+			default:
+				return fmt.Errorf("%w: unknown option '%s' in %s", ErrInvalidIndexTag, option, tableSchemaBeeORM.GetEntityName())
+			}
+		}
+	}
+
+	switch stopWords := tableSchemaBeeORM.GetTag("ORM", "stopwords", "", ""); stopWords {
+	case "", "none":
+	case "default":
+		index.StopWords = nil
+	default:
+		for _, word := range strings.Split(stopWords, ",") {
+			if word = strings.TrimSpace(word); word != "" {
+				index.StopWords = append(index.StopWords, word)
+			}
+		}
+	}
+
+	index.DefaultLanguage = strings.ToLower(strings.TrimSpace(tableSchemaBeeORM.GetTag("ORM", "language", "", "")))
+
+	if index.DefaultLanguage != "" && !redisSearchLanguages[index.DefaultLanguage] {
+		return fmt.Errorf("%w: unsupported language '%s' in %s", ErrInvalidIndexTag, index.DefaultLanguage, tableSchemaBeeORM.GetEntityName())
+	}
+
+	return nil
+}
+
+// indexerQuery selects rows with ID greater than query parameter, to limits IDs from the top when it's not zero
+func (tableSchema *tableSchemaRedisSearch) indexerQuery(tableName string, columns []string, to uint64, limit int) string {
+	indexQuery := "SELECT `ID`"
+
+	for _, column := range columns {
+		indexQuery += ",`" + column + "`"
+	}
+
+	indexQuery += " FROM `" + tableName + "` WHERE `ID` > ?"
+	if to > 0 {
+		indexQuery += " AND `ID` <= " + strconv.FormatUint(to, 10)
+	}
+
 	if tableSchema.hasFakeDelete && !tableSchema.hasSearchableFakeDelete {
 		indexQuery += " AND FakeDelete = 0"
 	}
 
-	indexQuery += " ORDER BY `ID` LIMIT " + strconv.Itoa(entityIndexerPage)
+	return indexQuery + " ORDER BY `ID` LIMIT " + strconv.Itoa(limit)
+}
 
-	tableSchema.index.Indexer = func(engine beeorm.Engine, lastID uint64, pusher RedisSearchIndexPusher) (newID uint64, hasMore bool) {
-		results, def := engine.GetMysql(tableSchemaBeeORM.GetMysqlPool()).Query(indexQuery, lastID)
+//nolint //Function has too many statements
+func (tableSchema *tableSchemaRedisSearch) buildIndexer(mysqlPool, tableName string, indexColumns, suggestColumns, geoFields []string, to uint64) RedisSearchIndexerFunc {
+	geoColumns := tableSchema.geoColumns(geoFields)
+	indexQuery := tableSchema.indexerQuery(tableName, append(append(append([]string{}, indexColumns...), suggestColumns...), geoColumns...), to, entityIndexerPage)
+
+	return func(engine beeorm.Engine, lastID uint64, pusher RedisSearchIndexPusher) (newID uint64, hasMore bool) {
+		suggestCache := engine.GetRedis(tableSchema.searchCacheName)
+		suggestions := &redisSearchSuggestions{}
+
+		results, def := engine.GetMysql(mysqlPool).Query(indexQuery, lastID)
 		defer def()
 
 		total := 0
-		pointers := make([]interface{}, len(indexColumns)+1)
+		pointers := make([]interface{}, len(indexColumns)+len(suggestColumns)+len(geoColumns)+1)
 		v := uint64(0)
 		pointers[0] = &v
 
@@ -142,6 +315,16 @@ func (tableSchema *tableSchemaRedisSearch) buildRedisSearchIndex(tableSchemaBeeO
 			pointers[i+1] = tableSchema.mapBindToScanPointer[column]()
 		}
 
+		for i := range suggestColumns {
+			pointers[len(indexColumns)+i+1] = &sql.NullString{}
+		}
+
+		geoOffset := len(indexColumns) + len(suggestColumns) + 1
+
+		for i := range geoColumns {
+			pointers[geoOffset+i] = &sql.NullFloat64{}
+		}
+
 		for results.Next() {
 			results.Scan(pointers...)
 
@@ -149,20 +332,40 @@ func (tableSchema *tableSchemaRedisSearch) buildRedisSearchIndex(tableSchemaBeeO
 
 			pusher.NewDocument(tableSchema.index.Prefixes[0] + strconv.FormatUint(lastID, 10))
 
+			fakeDeleted := false
+
 			for i, column := range indexColumns {
 				val := tableSchema.mapPointerToValue[column](pointers[i+1])
-				pusher.setField(column, tableSchema.mapBindToRedisSearch[column](val))
+
+				if mapped := tableSchema.mapBindToRedisSearch[column](val); mapped != nil {
+					pusher.setField(column, mapped)
+
+					fakeDeleted = fakeDeleted || (column == "FakeDelete" && mapped == "true")
+				}
+			}
+
+			for i, name := range geoFields {
+				if mapped := geoValue(pointers[geoOffset+i*2], pointers[geoOffset+i*2+1]); mapped != nil {
+					pusher.setField(name, mapped)
+				}
 			}
 
 			pusher.PushDocument()
 
+			for i, column := range suggestColumns {
+				if !fakeDeleted {
+					value := pointers[len(indexColumns)+i+1].(*sql.NullString)
+					tableSchema.addSuggestion(suggestions, suggestCache, column, strings.TrimSpace(value.String), lastID)
+				}
+			}
+
 			total++
 		}
 
+		suggestions.flush(suggestCache)
+
 		return lastID, total == entityIndexerPage
 	}
-
-	return nil
 }
 
 func buildUintField(tableSchema *tableSchemaRedisSearch, columnName, typeName string, hasSortable, hasSearchable bool) {
@@ -373,6 +576,60 @@ func buildStringPointerField(
 	}
 }
 
+func applyStringFieldTags(tableSchema *tableSchemaRedisSearch, schema beeorm.SettableEntitySchema, columnName string) error {
+	field := tableSchema.index.field(columnName)
+
+	if weight := schema.GetTag(columnName, "weight", "", ""); weight != "" {
+		if field.Type != redisSearchIndexFieldText {
+			return fmt.Errorf("%w: weight requires text field %s.%s", ErrInvalidFieldTag, schema.GetEntityName(), columnName)
+		}
+
+		value, err := strconv.ParseFloat(weight, 64)
+		if err != nil || value <= 0 {
+			return fmt.Errorf("%w: invalid weight '%s' in field %s.%s", ErrInvalidFieldTag, weight, schema.GetEntityName(), columnName)
+		}
+
+		field.Weight = value
+	}
+
+	if phonetic := schema.GetTag(columnName, "phonetic", "", ""); phonetic != "" {
+		if field.Type != redisSearchIndexFieldText {
+			return fmt.Errorf("%w: phonetic requires text field %s.%s", ErrInvalidFieldTag, schema.GetEntityName(), columnName)
+		}
+
+		switch phonetic {
+		case RedisSearchPhoneticEnglish, RedisSearchPhoneticFrench, RedisSearchPhoneticPortuguese, RedisSearchPhoneticSpanish:
+			field.Phonetic = phonetic
+		default:
+			return fmt.Errorf("%w: invalid phonetic matcher '%s' in field %s.%s", ErrInvalidFieldTag, phonetic, schema.GetEntityName(), columnName)
+		}
+	}
+
+	field.WithSuffixTrie = schema.GetTag(columnName, "suffixtrie", "true", "") == "true"
+	field.UNF = schema.GetTag(columnName, "unf", "true", "") == "true"
+
+	return nil
+}
+
+// buildLanguageField keeps document language in the hash, unsupported values are skipped so the default language is used
+func buildLanguageField(tableSchema *tableSchemaRedisSearch, columnName string) {
+	tableSchema.index.LanguageField = columnName
+	tableSchema.mapBindToRedisSearch[columnName] = func(val interface{}) interface{} {
+		if val == nil || val == "NULL" {
+			return nil
+		}
+
+		language := strings.ToLower(strings.TrimPrefix(strings.TrimSuffix(val.(string), `"`), `"`))
+		if !redisSearchLanguages[language] {
+			return nil
+		}
+
+		return language
+	}
+	tableSchema.mapBindToScanPointer[columnName] = scanStringNullablePointer
+	tableSchema.mapPointerToValue[columnName] = pointerStringNullableScan
+}
+
 func buildStringSliceField(tableSchema *tableSchemaRedisSearch, columnName string, hasSortable, hasSearchable bool) {
 	tableSchema.index.AddTagField(columnName, hasSortable, !hasSearchable, ",")
 	tableSchema.mapBindToRedisSearch[columnName] = defaultRedisSearchMapperNullableString
@@ -500,6 +757,105 @@ func buildPointersSliceField(tableSchema *tableSchemaRedisSearch, columnName str
 	}
 }
 
+func buildVectorField(tableSchema *tableSchemaRedisSearch, columnName, typeName, tag string) error {
+	options := strings.Split(tag, ",")
+	algorithm := strings.ToUpper(strings.TrimSpace(options[0]))
+	metric := ""
+	dim := 0
+
+	if algorithm != RedisSearchVectorAlgorithmFlat && algorithm != RedisSearchVectorAlgorithmHNSW {
+		return fmt.Errorf("%w: invalid vector algorithm '%s' in field %s", ErrInvalidVectorTag, options[0], columnName)
+	}
+
+	for _, option := range options[1:] {
+		parts := strings.SplitN(strings.TrimSpace(option), "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("%w: invalid vector option '%s' in field %s", ErrInvalidVectorTag, option, columnName)
+		}
+
+		switch parts[0] {
+		case "dim":
+			var err error
+
+			dim, err = strconv.Atoi(parts[1])
+			if err != nil || dim <= 0 {
+				return fmt.Errorf("%w: invalid vector dim '%s' in field %s", ErrInvalidVectorTag, parts[1], columnName)
+			}
+		case "metric":
+			metric = strings.ToUpper(parts[1])
+
+			if metric != RedisSearchVectorMetricL2 && metric != RedisSearchVectorMetricIP && metric != RedisSearchVectorMetricCosine {
+				return fmt.Errorf("%w: invalid vector metric '%s' in field %s", ErrInvalidVectorTag, parts[1], columnName)
+			}
+		default:
+			return fmt.Errorf("%w: unknown vector option '%s' in field %s", ErrInvalidVectorTag, parts[0], columnName)
+		}
+	}
+
+	if dim == 0 {
+		return fmt.Errorf("%w: missing vector dim in field %s", ErrInvalidVectorTag, columnName)
+	}
+
+	if metric == "" {
+		metric = RedisSearchVectorMetricCosine
+	}
+
+	vectorType := RedisSearchVectorTypeFloat32
+	if typeName == "[]float64" {
+		vectorType = RedisSearchVectorTypeFloat64
+	}
+
+	tableSchema.index.AddVectorField(columnName, algorithm, dim, metric, vectorType)
+	tableSchema.mapBindToRedisSearch[columnName] = func(val interface{}) interface{} {
+		if val == nil || val == "NULL" || val == "" {
+			return nil
+		}
+
+		var data []byte
+
+		switch v := val.(type) {
+		case string:
+			data = []byte(v)
+		case []byte:
+			data = v
+		default:
+			return nil
+		}
+
+		vector := make([]float64, 0, dim)
+
+		// invalid vector is not indexed, RediSearch would reject blob of different size anyway
+		if err := json.Unmarshal(data, &vector); err != nil || len(vector) != dim {
+			return nil
+		}
+
+		if vectorType == RedisSearchVectorTypeFloat64 {
+			return vectorFloat64ToBlob(vector)
+		}
+
+		vector32 := make([]float32, len(vector))
+		for i, v := range vector {
+			vector32[i] = float32(v)
+		}
+
+		return vectorToBlob(vector32)
+	}
+	tableSchema.mapBindToScanPointer[columnName] = scanStringNullablePointer
+	tableSchema.mapPointerToValue[columnName] = pointerStringNullableScan
+
+	return nil
+}
+
+func getVectorTag(structField reflect.StructField) string {
+	for _, part := range strings.Split(structField.Tag.Get("orm"), ";") {
+		if strings.HasPrefix(part, "vector=") {
+			return strings.TrimPrefix(part, "vector=")
+		}
+	}
+
+	return ""
+}
+
 var defaultRedisSearchMapper = func(val interface{}) interface{} {
 	return val
 }
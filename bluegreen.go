@@ -0,0 +1,281 @@
+package redisearch
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/latolukasz/beeorm/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisSearchIndexVersionKeyPrefix  = "_orm_index_version"
+	redisSearchIndexBuildingKeyPrefix = "_orm_index_building"
+	redisSearchIndexVersionsCacheTTL  = time.Second
+)
+
+// indexVersionsCache keeps versions used by flushed entities, so flush doesn't read them from redis every time
+var indexVersionsCache = struct {
+	mu       sync.Mutex
+	versions map[*RedisSearchIndex]cachedIndexVersions
+}{versions: map[*RedisSearchIndex]cachedIndexVersions{}}
+
+type cachedIndexVersions struct {
+	active   uint64
+	building uint64
+	expires  time.Time
+}
+
+func versionedIndexName(name string, version uint64) string {
+	return name + "_v" + strconv.FormatUint(version, 10)
+}
+
+func versionedPrefix(prefix string, version uint64) string {
+	return prefix + "v" + strconv.FormatUint(version, 10) + ":"
+}
+
+// versioned returns copy of blue/green index definition with physical name and key prefixes of given version
+func (rs *RedisSearchIndex) versioned(version uint64) *RedisSearchIndex {
+	index := *rs
+	index.Name = versionedIndexName(rs.Name, version)
+	index.Prefixes = make([]string, len(rs.Prefixes))
+
+	for i, prefix := range rs.Prefixes {
+		index.Prefixes[i] = versionedPrefix(prefix, version)
+	}
+
+	return &index
+}
+
+// versionedKeys maps document key to keys in given index versions, key is returned as it is for versions equal zero
+func (rs *RedisSearchIndex) versionedKeys(key string, versions ...uint64) []string {
+	keys := make([]string, 0, len(versions))
+
+	for _, version := range versions {
+		if version == 0 {
+			continue
+		}
+
+		versionedKey := key
+
+		for _, prefix := range rs.Prefixes {
+			if strings.HasPrefix(key, prefix) {
+				versionedKey = versionedPrefix(prefix, version) + key[len(prefix):]
+
+				break
+			}
+		}
+
+		keys = append(keys, versionedKey)
+	}
+
+	return keys
+}
+
+// IndexVersions returns active and currently built version of blue/green index, zero means no version
+func (r *RedisSearchEngine) IndexVersions(index string) (active, building uint64) {
+	return indexVersions(r.redis, index)
+}
+
+func indexVersions(redisCache beeorm.RedisCache, index string) (active, building uint64) {
+	values := redisCache.MGet(redisSearchIndexVersionKeyPrefix+index, redisSearchIndexBuildingKeyPrefix+index)
+
+	if value, ok := values[0].(string); ok {
+		active, _ = strconv.ParseUint(value, 10, 64)
+	}
+
+	if value, ok := values[1].(string); ok {
+		building, _ = strconv.ParseUint(value, 10, 64)
+	}
+
+	return active, building
+}
+
+// cachedIndexVersionsOf returns versions of blue/green index which are at most redisSearchIndexVersionsCacheTTL old
+func cachedIndexVersionsOf(redisCache beeorm.RedisCache, def *RedisSearchIndex) (active, building uint64) {
+	indexVersionsCache.mu.Lock()
+	defer indexVersionsCache.mu.Unlock()
+
+	cached, has := indexVersionsCache.versions[def]
+	if has && time.Now().Before(cached.expires) {
+		return cached.active, cached.building
+	}
+
+	active, building = indexVersions(redisCache, def.Name)
+	indexVersionsCache.versions[def] = cachedIndexVersions{active: active, building: building, expires: time.Now().Add(redisSearchIndexVersionsCacheTTL)}
+
+	return active, building
+}
+
+// versionsChangedE drops cached versions and waits until caches of other processes expire
+func (r *RedisSearchEngine) versionsChangedE(def *RedisSearchIndex) error {
+	indexVersionsCache.mu.Lock()
+	delete(indexVersionsCache.versions, def)
+	indexVersionsCache.mu.Unlock()
+
+	timer := time.NewTimer(redisSearchIndexVersionsCacheTTL)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-r.ctx.Done():
+		return r.ctx.Err()
+	}
+}
+
+// IndexPusher returns pusher which writes documents to all active versions of the index
+func (r *RedisSearchEngine) IndexPusher(index string) RedisSearchIndexPusher {
+	pusher := NewRedisSearchIndexPusher(r.engine, r.pool).(*redisSearchIndexPusher)
+	pusher.rewriteKey = r.keyRewriter(index, false)
+
+	return pusher
+}
+
+// IndexJSONPusher returns JSON pusher which writes documents to all active versions of the index
+func (r *RedisSearchEngine) IndexJSONPusher(index string) RedisSearchIndexJSONPusher {
+	pusher := NewRedisSearchIndexJSONPusher(r.engine, r.pool).(*redisSearchIndexJSONPusher)
+	pusher.rewriteKey = r.keyRewriter(index, false)
+
+	return pusher
+}
+
+func (r *RedisSearchEngine) keyRewriter(index string, onlyBuilding bool) *indexKeyRewriter {
+	def, has := r.redisSearchIndices[index]
+	if !has || !def.BlueGreen {
+		return nil
+	}
+
+	return &indexKeyRewriter{redis: r.redis, def: def, onlyBuilding: onlyBuilding}
+}
+
+// indexKeyRewriter maps document keys to versions of blue/green index, versions are resolved again after every flush
+type indexKeyRewriter struct {
+	redis        beeorm.RedisCache
+	def          *RedisSearchIndex
+	onlyBuilding bool
+	resolved     bool
+	versions     []uint64
+}
+
+func (k *indexKeyRewriter) keys(key string) []string {
+	if !k.resolved {
+		active, building := indexVersions(k.redis, k.def.Name)
+
+		if k.onlyBuilding {
+			if building != 0 {
+				active = 0
+			} else {
+				building = 0
+			}
+		}
+
+		k.versions = []uint64{active, building}
+		k.resolved = true
+	}
+
+	return k.def.versionedKeys(key, k.versions...)
+}
+
+func (k *indexKeyRewriter) reset() {
+	if k != nil {
+		k.resolved = false
+	}
+}
+
+func (r *RedisSearchEngine) forceReindexBlueGreenE(def *RedisSearchIndex) error {
+	active, building := r.IndexVersions(def.Name)
+
+	if active == 0 {
+		if err := r.dropIndexE(def.Name, true); err != nil {
+			return err
+		}
+
+		if err := r.createIndexE(def.versioned(1)); err != nil {
+			return err
+		}
+
+		if err := r.aliasE("FT.ALIASADD", def.Name, versionedIndexName(def.Name, 1)); err != nil {
+			return err
+		}
+
+		r.redis.Set(redisSearchIndexVersionKeyPrefix+def.Name, "1", 0)
+
+		return r.versionsChangedE(def)
+	}
+
+	if building != 0 {
+		if err := r.dropIndexE(versionedIndexName(def.Name, building), true); err != nil {
+			return err
+		}
+	}
+
+	next := active + 1
+	if building >= next {
+		next = building + 1
+	}
+
+	if err := r.createIndexE(def.versioned(next)); err != nil {
+		return err
+	}
+
+	r.redis.Set(redisSearchIndexBuildingKeyPrefix+def.Name, strconv.FormatUint(next, 10), 0)
+	r.redis.Del(redisSearchForceIndexLastIDKeyPrefix + def.Name)
+
+	// flushed entities must write to the new version before indexer reads their rows
+	return r.versionsChangedE(def)
+}
+
+// switchIndexVersionE points alias to the version built by indexer and removes previous version
+func (r *RedisSearchEngine) switchIndexVersionE(def *RedisSearchIndex) error {
+	active, building := r.IndexVersions(def.Name)
+	if building == 0 {
+		return nil
+	}
+
+	if err := r.aliasE("FT.ALIASUPDATE", def.Name, versionedIndexName(def.Name, building)); err != nil {
+		return err
+	}
+
+	r.redis.Set(redisSearchIndexVersionKeyPrefix+def.Name, strconv.FormatUint(building, 10), 0)
+	r.redis.Del(redisSearchIndexBuildingKeyPrefix + def.Name)
+
+	if active == 0 {
+		return nil
+	}
+
+	// documents written to previous version from stale caches are removed together with it
+	if err := r.versionsChangedE(def); err != nil {
+		return err
+	}
+
+	return r.dropIndexE(versionedIndexName(def.Name, active), true)
+}
+
+func (r *RedisSearchEngine) aliasE(command, alias, index string) error {
+	cmd := redis.NewStatusCmd(r.ctx, command, r.redis.AddNamespacePrefix(alias), r.redis.AddNamespacePrefix(index))
+
+	return r.process(cmd, command, "")
+}
+
+// resolveVersionedIndex returns blue/green definition and version of physical index name
+func (r *RedisSearchEngine) resolveVersionedIndex(name string) (def *RedisSearchIndex, version uint64) {
+	pos := strings.LastIndex(name, "_v")
+	if pos <= 0 {
+		return nil, 0
+	}
+
+	version, err := strconv.ParseUint(name[pos+2:], 10, 64)
+	if err != nil {
+		return nil, 0
+	}
+
+	def, has := r.redisSearchIndices[name[:pos]]
+	if !has || !def.BlueGreen {
+		return nil, 0
+	}
+
+	return def, version
+}
@@ -0,0 +1,86 @@
+package redisearch
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrUnknownIndex         = errors.New("unknown index")
+	ErrSyntax               = errors.New("syntax error")
+	ErrTimeout              = errors.New("timeout")
+	ErrIndexExists          = errors.New("index already exists")
+	ErrFieldNotSortable     = errors.New("field not sortable")
+	ErrLimitExceeded        = errors.New("limit exceeded")
+	ErrModuleMissing        = errors.New("redisearch module missing")
+	ErrEntityNotSearchable  = errors.New("entity is not searchable")
+	ErrEntityNotRegistered  = errors.New("entity is not registered")
+	ErrUnknownField         = errors.New("unknown field")
+	ErrMissingSearchableTag = errors.New("missing `searchable` tag")
+	ErrFilterNotAllowed     = errors.New("filter not allowed")
+	ErrInvalidQuery         = errors.New("invalid query")
+	ErrMissingPager         = errors.New("missing pager in redis search query")
+	ErrMissingPrefix        = errors.New("missing redis search prefix")
+	ErrUnexpectedReply      = errors.New("unexpected redis search reply")
+	ErrIndexerLoop          = errors.New("loop detected in indexer")
+	ErrUnsupportedFieldType = errors.New("unsupported field type")
+	ErrInvalidVectorTag     = errors.New("invalid vector tag")
+	ErrInvalidFieldTag      = errors.New("invalid field tag")
+	ErrInvalidIndexTag      = errors.New("invalid index tag")
+	ErrReindexInProgress    = errors.New("reindex in progress")
+	ErrThrottleTimeout      = errors.New("reindex throttle timeout")
+)
+
+// RedisSearchError keeps the original Redis error message and unwraps to one of the Err* sentinels above
+type RedisSearchError struct {
+	Err     error
+	Message string
+}
+
+func (e *RedisSearchError) Error() string {
+	return e.Message
+}
+
+func (e *RedisSearchError) Unwrap() error {
+	return e.Err
+}
+
+var redisSearchErrorPatterns = []struct {
+	pattern string
+	err     error
+}{
+	{"unknown index name", ErrUnknownIndex},
+	{"unknown index ", ErrUnknownIndex},
+	{"no such index", ErrUnknownIndex},
+	{"syntax error", ErrSyntax},
+	{"timeout", ErrTimeout},
+	{"index already exists", ErrIndexExists},
+	{"not sortable", ErrFieldNotSortable},
+	{"exceeds maximum", ErrLimitExceeded},
+	{"limit exceeded", ErrLimitExceeded},
+	{"unknown command 'ft.", ErrModuleMissing},
+	{"unknown command `ft.", ErrModuleMissing},
+}
+
+func parseRedisSearchError(err error) error {
+	if err == nil || errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	var redisErr redis.Error
+	if !errors.As(err, &redisErr) {
+		return err
+	}
+
+	message := strings.ToLower(err.Error())
+
+	for _, row := range redisSearchErrorPatterns {
+		if strings.Contains(message, row.pattern) {
+			return &RedisSearchError{Err: row.err, Message: err.Error()}
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,273 @@
+package redisearch
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/latolukasz/beeorm/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+type RedisSearchProfile struct {
+	Total                uint64
+	TotalProfileTime     float64
+	ParsingTime          float64
+	PipelineCreationTime float64
+	Iterators            *RedisSearchProfileIterator
+	ResultProcessors     []*RedisSearchProfileResultProcessor
+}
+
+type RedisSearchProfileIterator struct {
+	Type      string
+	QueryType string
+	Term      string
+	Time      float64
+	Counter   int64
+	Size      int64
+	Children  []*RedisSearchProfileIterator
+}
+
+type RedisSearchProfileResultProcessor struct {
+	Type    string
+	Time    float64
+	Counter int64
+}
+
+func (r *RedisSearchEngine) Explain(index string, query *RedisSearchQuery) string {
+	plan, err := r.ExplainE(index, query)
+	checkError(err)
+
+	return plan
+}
+
+func (r *RedisSearchEngine) ExplainE(index string, query *RedisSearchQuery) (string, error) {
+	args, err := query.buildQueryArgs([]interface{}{"FT.EXPLAIN", r.redis.AddNamespacePrefix(index)}, true)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := redis.NewStringCmd(r.ctx, args...)
+
+	if err = r.process(cmd, "FT.EXPLAIN", ""); err != nil {
+		return "", err
+	}
+
+	return cmd.Result()
+}
+
+func (r *RedisSearchEngine) ExplainCli(index string, query *RedisSearchQuery) []string {
+	plan, err := r.ExplainCliE(index, query)
+	checkError(err)
+
+	return plan
+}
+
+func (r *RedisSearchEngine) ExplainCliE(index string, query *RedisSearchQuery) ([]string, error) {
+	args, err := query.buildQueryArgs([]interface{}{"FT.EXPLAINCLI", r.redis.AddNamespacePrefix(index)}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := redis.NewStringSliceCmd(r.ctx, args...)
+
+	if err = r.process(cmd, "FT.EXPLAINCLI", ""); err != nil {
+		return nil, err
+	}
+
+	return cmd.Result()
+}
+
+func (r *RedisSearchEngine) Profile(index string, query *RedisSearchQuery, pager *beeorm.Pager) *RedisSearchProfile {
+	profile, err := r.ProfileE(index, query, pager)
+	checkError(err)
+
+	return profile
+}
+
+func (r *RedisSearchEngine) ProfileE(index string, query *RedisSearchQuery, pager *beeorm.Pager) (*RedisSearchProfile, error) {
+	index = r.redis.AddNamespacePrefix(index)
+
+	args, err := query.compile(index, false, r.redis.AddNamespacePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.profile(index, "SEARCH", args[2:], pager)
+}
+
+func (r *RedisSearchEngine) ProfileAggregate(index string, query *RedisSearchAggregation, pager *beeorm.Pager) *RedisSearchProfile {
+	profile, err := r.ProfileAggregateE(index, query, pager)
+	checkError(err)
+
+	return profile
+}
+
+func (r *RedisSearchEngine) ProfileAggregateE(index string, query *RedisSearchAggregation, pager *beeorm.Pager) (*RedisSearchProfile, error) {
+	index = r.redis.AddNamespacePrefix(index)
+
+	args, err := query.compile(index)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.profile(index, "AGGREGATE", args[2:], pager)
+}
+
+func (r *RedisSearchEngine) profile(index, command string, queryArgs []interface{}, pager *beeorm.Pager) (*RedisSearchProfile, error) {
+	args := []interface{}{"FT.PROFILE", index, command, "QUERY"}
+	args = append(args, queryArgs...)
+
+	args, err := r.applyPager(pager, args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := redis.NewSliceCmd(r.ctx, args...)
+
+	if err = r.process(cmd, "FT.PROFILE", ""); err != nil {
+		return nil, err
+	}
+
+	res, err := cmd.Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) != 2 {
+		return nil, fmt.Errorf("%w: profile reply with %d elements", ErrUnexpectedReply, len(res))
+	}
+
+	profile := &RedisSearchProfile{}
+
+	if results, ok := res[0].([]interface{}); ok && len(results) > 0 {
+		if total, ok := results[0].(int64); ok {
+			profile.Total = uint64(total)
+		}
+	}
+
+	sections, ok := res[1].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: profile section is not an array", ErrUnexpectedReply)
+	}
+
+	for _, section := range sections {
+		row, ok := section.([]interface{})
+		if !ok || len(row) < 2 {
+			continue
+		}
+
+		name, _ := row[0].(string)
+
+		switch name {
+		case "Total profile time":
+			profile.TotalProfileTime = parseReplyFloat(row[1])
+		case "Parsing time":
+			profile.ParsingTime = parseReplyFloat(row[1])
+		case "Pipeline creation time":
+			profile.PipelineCreationTime = parseReplyFloat(row[1])
+		case "Iterators profile":
+			if iterator, ok := row[1].([]interface{}); ok {
+				profile.Iterators = parseProfileIterator(iterator)
+			}
+		case "Result processors profile":
+			for _, processor := range row[1:] {
+				if processorRow, ok := processor.([]interface{}); ok {
+					profile.ResultProcessors = append(profile.ResultProcessors, parseProfileResultProcessor(processorRow))
+				}
+			}
+		}
+	}
+
+	return profile, nil
+}
+
+func parseProfileIterator(row []interface{}) *RedisSearchProfileIterator {
+	iterator := &RedisSearchProfileIterator{}
+
+	for i := 0; i < len(row)-1; i += 2 {
+		key, _ := row[i].(string)
+
+		switch key {
+		case "Type":
+			iterator.Type = parseReplyString(row[i+1])
+		case "Query type":
+			iterator.QueryType = parseReplyString(row[i+1])
+		case "Term":
+			iterator.Term = parseReplyString(row[i+1])
+		case "Time":
+			iterator.Time = parseReplyFloat(row[i+1])
+		case "Counter":
+			iterator.Counter = parseReplyInt(row[i+1])
+		case "Size":
+			iterator.Size = parseReplyInt(row[i+1])
+		case "Child iterators", "Child iterator":
+			for _, child := range row[i+1:] {
+				if childRow, ok := child.([]interface{}); ok {
+					iterator.Children = append(iterator.Children, parseProfileIterator(childRow))
+				}
+			}
+
+			return iterator
+		}
+	}
+
+	return iterator
+}
+
+func parseProfileResultProcessor(row []interface{}) *RedisSearchProfileResultProcessor {
+	processor := &RedisSearchProfileResultProcessor{}
+
+	for i := 0; i < len(row)-1; i += 2 {
+		key, _ := row[i].(string)
+
+		switch key {
+		case "Type":
+			processor.Type = parseReplyString(row[i+1])
+		case "Time":
+			processor.Time = parseReplyFloat(row[i+1])
+		case "Counter":
+			processor.Counter = parseReplyInt(row[i+1])
+		}
+	}
+
+	return processor
+}
+
+func parseReplyString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	}
+
+	return ""
+}
+
+func parseReplyFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+
+		return f
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	}
+
+	return 0
+}
+
+func parseReplyInt(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case string:
+		i, _ := strconv.ParseInt(v, 10, 64)
+
+		return i
+	}
+
+	return 0
+}
@@ -0,0 +1,159 @@
+package redisearch
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/latolukasz/beeorm/v2"
+)
+
+const redisSearchMaxGeoLatitude = 85.05112878
+
+type redisSearchGeoField struct {
+	lon string
+	lat string
+}
+
+// parseGeoTag parses `geo=Location:Lon,Lat` tag, more fields are separated with `|`
+func parseGeoTag(schema beeorm.SettableEntitySchema, tableSchema *tableSchemaRedisSearch) error {
+	tag := schema.GetTag("ORM", "geo", "", "")
+	if tag == "" {
+		return nil
+	}
+
+	for _, definition := range strings.Split(tag, "|") {
+		parts := strings.SplitN(definition, ":", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("%w: invalid geo tag '%s' in %s", ErrInvalidIndexTag, definition, schema.GetEntityName())
+		}
+
+		columns := strings.Split(parts[1], ",")
+		if len(columns) != 2 || parts[0] == "" {
+			return fmt.Errorf("%w: invalid geo tag '%s' in %s", ErrInvalidIndexTag, definition, schema.GetEntityName())
+		}
+
+		name := strings.TrimSpace(parts[0])
+		field := redisSearchGeoField{lon: strings.TrimSpace(columns[0]), lat: strings.TrimSpace(columns[1])}
+
+		if _, has := tableSchema.columnMapping[name]; has {
+			return fmt.Errorf("%w: geo field %s.%s conflicts with column", ErrInvalidIndexTag, schema.GetEntityName(), name)
+		}
+
+		for _, column := range []string{field.lon, field.lat} {
+			structField, has := schema.GetType().FieldByName(column)
+			if !has {
+				return fmt.Errorf("%w: unknown geo column %s.%s", ErrInvalidIndexTag, schema.GetEntityName(), column)
+			}
+
+			switch structField.Type.String() {
+			case "float32", "float64", "*float32", "*float64":
+			default:
+				return fmt.Errorf("%w: %s in geo column %s.%s", ErrUnsupportedFieldType, structField.Type.String(), schema.GetEntityName(), column)
+			}
+		}
+
+		if tableSchema.index == nil {
+			tableSchema.index = &RedisSearchIndex{}
+		}
+
+		tableSchema.index.AddGeoField(name, false, false)
+		tableSchema.geoFields[name] = field
+	}
+
+	return nil
+}
+
+func (tableSchema *tableSchemaRedisSearch) geoColumns(fields []string) []string {
+	columns := make([]string, 0, len(fields)*2)
+
+	for _, name := range fields {
+		columns = append(columns, tableSchema.geoFields[name].lon, tableSchema.geoFields[name].lat)
+	}
+
+	return columns
+}
+
+// completeGeoBind returns copy of bind with coordinate which is missing when only one of geo columns was changed,
+// bind is shared with other flush consumers, so it's never modified
+func (tableSchema *tableSchemaRedisSearch) completeGeoBind(engine beeorm.Engine, entitySchema beeorm.EntitySchema, bind beeorm.Bind, id uint64) beeorm.Bind {
+	completed := bind
+	copied := false
+
+	for _, name := range sortedKeys(tableSchema.geoFields) {
+		field := tableSchema.geoFields[name]
+		_, hasLon := bind[field.lon]
+		_, hasLat := bind[field.lat]
+
+		if hasLon == hasLat {
+			continue
+		}
+
+		missing := field.lon
+		if hasLon {
+			missing = field.lat
+		}
+
+		value := sql.NullFloat64{}
+
+		engine.GetMysql(entitySchema.GetMysqlPool()).QueryRow(
+			beeorm.NewWhere("SELECT `"+missing+"` FROM `"+entitySchema.GetTableName()+"` WHERE `ID` = ?", id),
+			&value,
+		)
+
+		if !copied {
+			completed = make(beeorm.Bind, len(bind)+1)
+
+			for column, val := range bind {
+				completed[column] = val
+			}
+
+			copied = true
+		}
+
+		if value.Valid {
+			completed[missing] = value.Float64
+		} else {
+			completed[missing] = nil
+		}
+	}
+
+	return completed
+}
+
+// geoValue returns `lon,lat` value of GEO field or nil when coordinates are empty or invalid
+func geoValue(lon, lat interface{}) interface{} {
+	lonValue, ok := geoCoordinate(lon)
+	if !ok || lonValue < -180 || lonValue > 180 {
+		return nil
+	}
+
+	latValue, ok := geoCoordinate(lat)
+	if !ok || latValue < -redisSearchMaxGeoLatitude || latValue > redisSearchMaxGeoLatitude {
+		return nil
+	}
+
+	return strconv.FormatFloat(lonValue, 'f', 6, 64) + "," + strconv.FormatFloat(latValue, 'f', 6, 64)
+}
+
+func geoCoordinate(val interface{}) (float64, bool) {
+	switch v := val.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case *sql.NullFloat64:
+		return v.Float64, v.Valid
+	case string:
+		if v == "NULL" || v == "" {
+			return 0, false
+		}
+
+		f, err := strconv.ParseFloat(v, 64)
+
+		return f, err == nil
+	}
+
+	return 0, false
+}
@@ -2,9 +2,41 @@ package redisearch
 
 import "github.com/latolukasz/beeorm/v2"
 
+const (
+	RedisSearchIndexKeyTypeHash = "HASH"
+	RedisSearchIndexKeyTypeJSON = "JSON"
+
+	RedisSearchVectorAlgorithmFlat = "FLAT"
+	RedisSearchVectorAlgorithmHNSW = "HNSW"
+
+	RedisSearchVectorTypeFloat32 = "FLOAT32"
+	RedisSearchVectorTypeFloat64 = "FLOAT64"
+
+	RedisSearchVectorMetricL2     = "L2"
+	RedisSearchVectorMetricIP     = "IP"
+	RedisSearchVectorMetricCosine = "COSINE"
+
+	RedisSearchGeoShapeFlat      = "FLAT"
+	RedisSearchGeoShapeSpherical = "SPHERICAL"
+
+	RedisSearchPhoneticEnglish    = "dm:en"
+	RedisSearchPhoneticFrench     = "dm:fr"
+	RedisSearchPhoneticPortuguese = "dm:pt"
+	RedisSearchPhoneticSpanish    = "dm:es"
+)
+
+var redisSearchLanguages = map[string]bool{
+	"arabic": true, "armenian": true, "basque": true, "catalan": true, "chinese": true, "danish": true, "dutch": true,
+	"english": true, "finnish": true, "french": true, "german": true, "greek": true, "hindi": true, "hungarian": true,
+	"indonesian": true, "irish": true, "italian": true, "lithuanian": true, "nepali": true, "norwegian": true,
+	"portuguese": true, "romanian": true, "russian": true, "serbian": true, "spanish": true, "swedish": true,
+	"tamil": true, "turkish": true, "yiddish": true,
+}
+
 type RedisSearchIndex struct {
 	Name            string
 	RedisPool       string
+	KeyType         string
 	Prefixes        []string
 	DefaultLanguage string
 	LanguageField   string
@@ -16,23 +48,46 @@ type RedisSearchIndex struct {
 	NoFields        bool
 	NoFreqs         bool
 	SkipInitialScan bool
+	BlueGreen       bool
 	StopWords       []string
+	Synonyms        map[string][]string
 	Fields          []RedisSearchIndexField
-	Indexer         RedisSearchIndexerFunc `json:"-"`
+	Indexer         RedisSearchIndexerFunc            `json:"-"`
+	JSONIndexer     RedisSearchJSONIndexerFunc        `json:"-"`
+	MaxID           func(engine beeorm.Engine) uint64 `json:"-"`
+	Throttle        *RedisSearchReindexThrottle
+
+	fieldsIndexer func(fields []string) RedisSearchIndexerFunc
+	rangeIndexer  func(to uint64) RedisSearchIndexerFunc
+	dictionaries  []string
 }
 
 type RedisSearchIndexField struct {
 	Type         string
 	Name         string
+	Path         string
 	Sortable     bool
 	NoIndex      bool
 	NoStem       bool
 	Weight       float64
 	TagSeparator string
+
+	Phonetic       string
+	WithSuffixTrie bool
+	UNF            bool
+
+	CoordSystem string
+
+	VectorAlgorithm      string
+	VectorType           string
+	VectorDim            int
+	VectorDistanceMetric string
 }
 
 type RedisSearchIndexerFunc func(engine beeorm.Engine, lastID uint64, pusher RedisSearchIndexPusher) (newID uint64, hasMore bool)
 
+type RedisSearchJSONIndexerFunc func(engine beeorm.Engine, lastID uint64, pusher RedisSearchIndexJSONPusher) (newID uint64, hasMore bool)
+
 func (rs *RedisSearchIndex) AddTextField(name string, weight float64, sortable, noindex, nostem bool) {
 	rs.Fields = append(rs.Fields, RedisSearchIndexField{
 		Type:     redisSearchIndexFieldText,
@@ -62,6 +117,15 @@ func (rs *RedisSearchIndex) AddGeoField(name string, sortable, noindex bool) {
 	})
 }
 
+// AddGeoShapeField adds field with WKT shapes, coordSystem is RedisSearchGeoShapeFlat or RedisSearchGeoShapeSpherical
+func (rs *RedisSearchIndex) AddGeoShapeField(name, coordSystem string) {
+	rs.Fields = append(rs.Fields, RedisSearchIndexField{
+		Type:        redisSearchIndexFieldGeoShape,
+		Name:        name,
+		CoordSystem: coordSystem,
+	})
+}
+
 func (rs *RedisSearchIndex) AddTagField(name string, sortable, noindex bool, separator string) {
 	rs.Fields = append(rs.Fields, RedisSearchIndexField{
 		Type:         redisSearchIndexFieldTAG,
@@ -71,3 +135,59 @@ func (rs *RedisSearchIndex) AddTagField(name string, sortable, noindex bool, sep
 		TagSeparator: separator,
 	})
 }
+
+func (rs *RedisSearchIndex) AddVectorField(name, algorithm string, dim int, metric, vectorType string) {
+	rs.Fields = append(rs.Fields, RedisSearchIndexField{
+		Type:                 redisSearchIndexFieldVector,
+		Name:                 name,
+		VectorAlgorithm:      algorithm,
+		VectorType:           vectorType,
+		VectorDim:            dim,
+		VectorDistanceMetric: metric,
+	})
+}
+
+func (rs *RedisSearchIndex) SetFieldWeight(name string, weight float64) {
+	if field := rs.field(name); field != nil {
+		field.Weight = weight
+	}
+}
+
+// SetFieldPhonetic enables phonetic matching in text field, matcher is one of RedisSearchPhonetic* values
+func (rs *RedisSearchIndex) SetFieldPhonetic(name, matcher string) {
+	if field := rs.field(name); field != nil {
+		field.Phonetic = matcher
+	}
+}
+
+func (rs *RedisSearchIndex) SetFieldWithSuffixTrie(name string) {
+	if field := rs.field(name); field != nil {
+		field.WithSuffixTrie = true
+	}
+}
+
+// SetFieldUNF disables normalization of sortable text and tag field values
+func (rs *RedisSearchIndex) SetFieldUNF(name string) {
+	if field := rs.field(name); field != nil {
+		field.UNF = true
+	}
+}
+
+func (rs *RedisSearchIndex) field(name string) *RedisSearchIndexField {
+	for i := range rs.Fields {
+		if rs.Fields[i].Name == name {
+			return &rs.Fields[i]
+		}
+	}
+
+	return nil
+}
+
+// SetFieldPath sets JSONPath of the field in JSON index, field name is used as its alias
+func (rs *RedisSearchIndex) SetFieldPath(name, path string) {
+	for i, field := range rs.Fields {
+		if field.Name == name {
+			rs.Fields[i].Path = path
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package redisearch
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/latolukasz/beeorm/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+type RedisSearchIndexJSONPusher interface {
+	SetDocument(key string, document interface{})
+	SetDocumentRaw(key string, document string)
+	DeleteDocuments(key ...string)
+	Flush()
+}
+
+type redisSearchIndexJSONPusher struct {
+	redis      beeorm.RedisCache
+	keys       []string
+	documents  []interface{}
+	deleted    []string
+	rewriteKey *indexKeyRewriter
+	pushed     uint64
+	throttle   *reindexThrottle
+	err        error
+}
+
+func NewRedisSearchIndexJSONPusher(ormService beeorm.Engine, pool string) RedisSearchIndexJSONPusher {
+	return &redisSearchIndexJSONPusher{redis: ormService.GetRedis(pool)}
+}
+
+func (p *redisSearchIndexJSONPusher) SetDocument(key string, document interface{}) {
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		p.err = err
+
+		return
+	}
+
+	p.SetDocumentRaw(key, string(encoded))
+}
+
+func (p *redisSearchIndexJSONPusher) SetDocumentRaw(key string, document string) {
+	if p.err != nil {
+		return
+	}
+
+	p.pushed++
+
+	keys := []string{key}
+	if p.rewriteKey != nil {
+		keys = p.rewriteKey.keys(key)
+	}
+
+	for _, k := range keys {
+		p.keys = append(p.keys, p.redis.AddNamespacePrefix(k))
+		p.documents = append(p.documents, document)
+	}
+
+	if p.throttle != nil && p.throttle.shouldWait(p.pushed) {
+		p.Flush()
+		p.err = p.throttle.wait(p.pushed)
+	}
+}
+
+func (p *redisSearchIndexJSONPusher) DeleteDocuments(key ...string) {
+	for _, k := range key {
+		keys := []string{k}
+		if p.rewriteKey != nil {
+			keys = p.rewriteKey.keys(k)
+		}
+
+		for _, deleted := range keys {
+			p.removePending(p.redis.AddNamespacePrefix(deleted))
+			p.deleted = append(p.deleted, deleted)
+		}
+	}
+}
+
+// removePending drops document which was set before it was deleted in the same flush
+func (p *redisSearchIndexJSONPusher) removePending(key string) {
+	for i := 0; i < len(p.keys); i++ {
+		if p.keys[i] == key {
+			p.keys = append(p.keys[:i], p.keys[i+1:]...)
+			p.documents = append(p.documents[:i], p.documents[i+1:]...)
+			i--
+		}
+	}
+}
+
+// Flush runs deletes first, so document set again after delete is kept
+func (p *redisSearchIndexJSONPusher) Flush() {
+	if len(p.deleted) > 0 {
+		p.redis.Del(p.deleted...)
+		p.deleted = p.deleted[:0]
+	}
+
+	// every key has own JSON.SET, one command with many keys fails with CROSSSLOT on Redis Cluster
+	for i, key := range p.keys {
+		cmd := redis.NewStatusCmd(context.Background(), "JSON.SET", key, "$", p.documents[i])
+		checkError(p.redis.Process(context.Background(), cmd))
+	}
+
+	p.keys = p.keys[:0]
+	p.documents = p.documents[:0]
+
+	p.rewriteKey.reset()
+}
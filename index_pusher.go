@@ -18,15 +18,22 @@ type RedisSearchIndexPusher interface {
 	SetFloat(key string, value float64)
 	SetBool(key string, value bool)
 	SetGeo(key string, lon float64, lat float64)
+	SetGeoShape(key string, wkt string)
+	SetVector(key string, vector []float32)
+	SetVectorFloat64(key string, vector []float64)
 	PushDocument()
 	Flush()
 	setField(key string, value interface{})
 }
 
 type redisSearchIndexPusher struct {
-	pipeline *beeorm.RedisPipeLine
-	key      string
-	fields   []interface{}
+	pipeline   *beeorm.RedisPipeLine
+	key        string
+	fields     []interface{}
+	rewriteKey *indexKeyRewriter
+	pushed     uint64
+	throttle   *reindexThrottle
+	err        error
 }
 
 func NewRedisSearchIndexPusher(ormService beeorm.Engine, pool string) RedisSearchIndexPusher {
@@ -38,6 +45,15 @@ func (p *redisSearchIndexPusher) NewDocument(key string) {
 }
 
 func (p *redisSearchIndexPusher) DeleteDocuments(key ...string) {
+	if p.rewriteKey != nil {
+		keys := make([]string, 0, len(key))
+		for _, k := range key {
+			keys = append(keys, p.rewriteKey.keys(k)...)
+		}
+
+		key = keys
+	}
+
 	p.pipeline.Del(key...)
 }
 
@@ -91,12 +107,47 @@ func (p *redisSearchIndexPusher) SetGeo(key string, lon float64, lat float64) {
 	p.fields = append(p.fields, key, lonS+","+latS)
 }
 
+func (p *redisSearchIndexPusher) SetGeoShape(key string, wkt string) {
+	p.fields = append(p.fields, key, wkt)
+}
+
+func (p *redisSearchIndexPusher) SetVector(key string, vector []float32) {
+	p.fields = append(p.fields, key, vectorToBlob(vector))
+}
+
+func (p *redisSearchIndexPusher) SetVectorFloat64(key string, vector []float64) {
+	p.fields = append(p.fields, key, vectorFloat64ToBlob(vector))
+}
+
 func (p *redisSearchIndexPusher) PushDocument() {
-	p.pipeline.HSet(p.key, p.fields...)
+	// documents are skipped after throttle failure, handler stops the indexer after current page
+	if len(p.fields) == 0 || p.err != nil {
+		p.key = ""
+		p.fields = p.fields[:0]
+
+		return
+	}
+
+	p.pushed++
+
+	if p.rewriteKey != nil {
+		for _, key := range p.rewriteKey.keys(p.key) {
+			p.pipeline.HSet(key, p.fields...)
+		}
+	} else {
+		p.pipeline.HSet(p.key, p.fields...)
+	}
+
 	p.key = ""
 	p.fields = p.fields[:0]
+
+	if p.throttle != nil && p.throttle.shouldWait(p.pushed) {
+		p.pipeline.Exec()
+		p.err = p.throttle.wait(p.pushed)
+	}
 }
 
 func (p *redisSearchIndexPusher) Flush() {
 	p.pipeline.Exec()
+	p.rewriteKey.reset()
 }
@@ -0,0 +1,260 @@
+package redisearch
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/latolukasz/beeorm/v2"
+)
+
+type redisSearchIterator struct {
+	engine            *RedisSearchEngine
+	redisSearchSchema *tableSchemaRedisSearch
+	query             *RedisSearchQuery
+	entityType        reflect.Type
+	batchSize         int
+	fn                func(entities interface{}) bool
+	references        []string
+	ids               []uint64
+	stopped           bool
+}
+
+// Iterate walks all entities matching query in batches, ordered by query sort field and ID.
+// fn receives slice of entities (for example []*entity.User) and can return false to stop iteration.
+func (r *RedisSearchEngine) Iterate(entity beeorm.Entity, query *RedisSearchQuery, batchSize int, fn func(entities interface{}) bool, references ...string) {
+	checkError(r.IterateE(entity, query, batchSize, fn, references...))
+}
+
+func (r *RedisSearchEngine) IterateE(
+	entity beeorm.Entity,
+	query *RedisSearchQuery,
+	batchSize int,
+	fn func(entities interface{}) bool,
+	references ...string,
+) error {
+	schema := r.engine.GetRegistry().GetEntitySchemaForEntity(entity)
+
+	redisSearchSchema, err := getRedisSearchSchema(schema)
+	if err != nil {
+		return err
+	}
+
+	if batchSize <= 0 {
+		return fmt.Errorf("%w: batch size must be greater than 0", ErrInvalidQuery)
+	}
+
+	if err := validateRedisSearchFilter(redisSearchSchema, "ID", redisSearchIndexFieldNumeric, "iterate"); err != nil {
+		return err
+	}
+
+	if query.sortField != "" && query.sortField != "ID" {
+		if err := validateRedisSearchFilter(redisSearchSchema, query.sortField, redisSearchIndexFieldNumeric, "iterate sort"); err != nil {
+			return err
+		}
+	}
+
+	if err := validateRedisSearchQuery(redisSearchSchema, query); err != nil {
+		return err
+	}
+
+	iterator := &redisSearchIterator{
+		engine:            r,
+		redisSearchSchema: redisSearchSchema,
+		query:             query,
+		entityType:        reflect.TypeOf(entity),
+		batchSize:         batchSize,
+		fn:                fn,
+		references:        references,
+		ids:               make([]uint64, 0, batchSize),
+	}
+
+	if query.sortField == "" || query.sortField == "ID" {
+		err = iterator.iterateByID(nil, query.sortDesc)
+	} else {
+		err = iterator.iterateBySortField()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	iterator.flush()
+
+	return nil
+}
+
+func (i *redisSearchIterator) iterateBySortField() error {
+	field := i.query.sortField
+	desc := i.query.sortDesc
+	last := ""
+
+	for !i.stopped {
+		keyset := NewRedisSearchQuery()
+
+		if last != "" {
+			if desc {
+				keyset.filterNumericMinMax(field, "-inf", "("+last)
+			} else {
+				keyset.filterNumericMinMax(field, "("+last, "+inf")
+			}
+		}
+
+		query := i.clone(keyset)
+		query.Sort(field, desc)
+		query.toReturn = []interface{}{field}
+
+		_, rows, err := i.engine.search(i.redisSearchSchema.index.Name, query, beeorm.NewPager(1, i.batchSize), false)
+		if err != nil {
+			return err
+		}
+
+		if len(rows) == 0 {
+			return nil
+		}
+
+		ids := make([]uint64, 0, len(rows)/2)
+		values := make([]string, 0, len(rows)/2)
+
+		for k := 0; k < len(rows)-1; k += 2 {
+			id, err := documentID(rows[k].(string))
+			if err != nil {
+				return err
+			}
+
+			value := ""
+			fields, _ := rows[k+1].([]interface{})
+
+			for f := 0; f < len(fields)-1; f += 2 {
+				if fields[f] == field {
+					value, _ = fields[f+1].(string)
+				}
+			}
+
+			ids = append(ids, id)
+			values = append(values, value)
+		}
+
+		last = values[len(values)-1]
+		if last == "" {
+			return fmt.Errorf("%w: missing value of sort field %s", ErrUnexpectedReply, field)
+		}
+
+		// order of rows with equal sort value is undefined, so each group is sorted by ID
+		for start := 0; start < len(ids) && values[start] != last; {
+			end := start + 1
+			for end < len(ids) && values[end] == values[start] {
+				end++
+			}
+
+			group := ids[start:end]
+			sort.Slice(group, func(a, b int) bool {
+				return group[a] < group[b]
+			})
+
+			for _, id := range group {
+				i.push(id)
+			}
+
+			start = end
+		}
+
+		tie := NewRedisSearchQuery()
+		tie.filterNumericMinMax(field, last, last)
+
+		if err := i.iterateByID(tie, false); err != nil {
+			return err
+		}
+
+		if len(ids) < i.batchSize {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (i *redisSearchIterator) iterateByID(filter *RedisSearchQuery, desc bool) error {
+	lastID := uint64(0)
+
+	for !i.stopped {
+		keyset := NewRedisSearchQuery()
+
+		if filter != nil {
+			keyset.Where(filter)
+		}
+
+		if lastID > 0 {
+			if desc {
+				keyset.FilterUintLess("ID", lastID)
+			} else {
+				keyset.FilterUintGreater("ID", lastID)
+			}
+		}
+
+		query := i.clone(keyset)
+		query.Sort("ID", desc)
+
+		_, rows, err := i.engine.search(i.redisSearchSchema.index.Name, query, beeorm.NewPager(1, i.batchSize), true)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			lastID, err = documentID(row.(string))
+			if err != nil {
+				return err
+			}
+
+			i.push(lastID)
+		}
+
+		if len(rows) < i.batchSize {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (i *redisSearchIterator) clone(keyset *RedisSearchQuery) *RedisSearchQuery {
+	query := *i.query
+	query.hasFakeDelete = i.redisSearchSchema.hasSearchableFakeDelete
+	query.toReturn = nil
+	query.withScores = false
+	query.explainScore = false
+	query.highlight = nil
+	query.summarize = nil
+
+	if keyset.filtersNumeric != nil || keyset.where != nil {
+		query.Where(keyset)
+	}
+
+	return &query
+}
+
+func (i *redisSearchIterator) push(id uint64) {
+	if i.stopped {
+		return
+	}
+
+	i.ids = append(i.ids, id)
+
+	if len(i.ids) >= i.batchSize {
+		i.flush()
+	}
+}
+
+func (i *redisSearchIterator) flush() {
+	if i.stopped || len(i.ids) == 0 {
+		return
+	}
+
+	entities := reflect.New(reflect.SliceOf(i.entityType))
+	i.engine.engine.LoadByIDs(i.ids, entities.Interface(), i.references...)
+	i.ids = i.ids[:0]
+
+	if !i.fn(entities.Elem().Interface()) {
+		i.stopped = true
+	}
+}
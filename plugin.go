@@ -1,6 +1,7 @@
 package redisearch
 
 import (
+	"fmt"
 	"reflect"
 	"strconv"
 	"sync"
@@ -25,12 +26,7 @@ func Init(pool string) *BeeormRedisearchPlugin {
 }
 
 func (p *BeeormRedisearchPlugin) RegisterCustomIndex(customIndex *RedisSearchIndex) {
-	customIndices, ok := customIndicesInit[p.pool]
-	if !ok {
-		customIndices = append(customIndices, customIndex)
-	}
-
-	customIndicesInit[p.pool] = customIndices
+	customIndicesInit[p.pool] = append(customIndicesInit[p.pool], customIndex)
 }
 
 func (p *BeeormRedisearchPlugin) GetCode() string {
@@ -53,6 +49,8 @@ func (p *BeeormRedisearchPlugin) InterfaceInitEntitySchema(schema beeorm.Settabl
 	redisSearchIndex := &tableSchemaRedisSearch{
 		index:                nil,
 		columnMapping:        map[string]int{},
+		suggestFields:        map[string]redisSearchSuggestField{},
+		geoFields:            map[string]redisSearchGeoField{},
 		mapBindToRedisSearch: map[string]func(val interface{}) interface{}{},
 		mapBindToScanPointer: map[string]func() interface{}{},
 		mapPointerToValue:    map[string]func(val interface{}) interface{}{},
@@ -60,6 +58,7 @@ func (p *BeeormRedisearchPlugin) InterfaceInitEntitySchema(schema beeorm.Settabl
 
 	hsaFakeDelete := false
 	hasSearchableFakeDelete := false
+	languageColumn := ""
 
 	for i, column := range schema.GetColumns() {
 		redisSearchIndex.columnMapping[column] = i
@@ -75,19 +74,38 @@ func (p *BeeormRedisearchPlugin) InterfaceInitEntitySchema(schema beeorm.Settabl
 			hasSearchableFakeDelete = isSearchable
 		}
 
-		if !isSearchable && !isSortable {
+		structField, ok := entityType.FieldByName(column)
+		if !ok {
 			continue
 		}
 
-		if redisSearchIndex.index == nil {
-			redisSearchIndex.index = &RedisSearchIndex{}
+		if suggest := schema.GetTag(column, "suggest", "", ""); suggest != "" {
+			if structField.Type.String() != "string" && structField.Type.String() != "*string" {
+				return fmt.Errorf("%w: %s in suggest field %s.%s", ErrUnsupportedFieldType, structField.Type.String(), schema.GetEntityName(), column)
+			}
+
+			redisSearchIndex.suggestFields[column] = redisSearchSuggestField{
+				dictionary: suggest,
+				payload:    schema.GetTag(column, "suggestPayload", "true", "") == "true",
+			}
 		}
 
-		structField, ok := entityType.FieldByName(column)
-		if !ok {
+		if schema.GetTag(column, "language", "true", "") == "true" {
+			if structField.Type.String() != "string" && structField.Type.String() != "*string" {
+				return fmt.Errorf("%w: %s in language field %s.%s", ErrUnsupportedFieldType, structField.Type.String(), schema.GetEntityName(), column)
+			}
+
+			languageColumn = column
+		}
+
+		if !isSearchable && !isSortable {
 			continue
 		}
 
+		if redisSearchIndex.index == nil {
+			redisSearchIndex.index = &RedisSearchIndex{}
+		}
+
 		kind := structField.Type.Kind()
 		typeName := structField.Type.Name()
 
@@ -129,10 +147,28 @@ func (p *BeeormRedisearchPlugin) InterfaceInitEntitySchema(schema beeorm.Settabl
 			buildIntPointerField(redisSearchIndex, column, typeName, isSortable, isSearchable)
 		case "string":
 			buildStringField(redisSearchIndex, column, isSortable, isSearchable, hasEnum, stem, hasStem)
+
+			if err := applyStringFieldTags(redisSearchIndex, schema, column); err != nil {
+				return err
+			}
 		case "*string":
 			buildStringPointerField(redisSearchIndex, column, isSortable, isSearchable, hasEnum, stem, hasStem)
+
+			if err := applyStringFieldTags(redisSearchIndex, schema, column); err != nil {
+				return err
+			}
 		case "[]string":
 			buildStringSliceField(redisSearchIndex, column, isSortable, isSearchable)
+		case "[]float32",
+			"[]float64":
+			vectorTag := getVectorTag(structField)
+			if vectorTag == "" {
+				return fmt.Errorf("%w: %s in field %s.%s requires vector tag", ErrUnsupportedFieldType, typeName, schema.GetEntityName(), column)
+			}
+
+			if err := buildVectorField(redisSearchIndex, column, typeName, vectorTag); err != nil {
+				return err
+			}
 		case "bool":
 			buildBoolField(redisSearchIndex, column, isSortable, isSearchable)
 		case "*bool":
@@ -152,23 +188,41 @@ func (p *BeeormRedisearchPlugin) InterfaceInitEntitySchema(schema beeorm.Settabl
 		default:
 			if isPointer {
 				buildPointerField(redisSearchIndex, column, isSortable, isSearchable)
-			} else {
+			} else if isSlice && structField.Type.Elem().Kind() == reflect.Pointer {
 				buildPointersSliceField(redisSearchIndex, column, isSortable, isSearchable)
+			} else {
+				return fmt.Errorf("%w: %s in field %s.%s", ErrUnsupportedFieldType, structField.Type.String(), schema.GetEntityName(), column)
 			}
 		}
 	}
 
+	if err := parseGeoTag(schema, redisSearchIndex); err != nil {
+		return err
+	}
+
 	if redisSearchIndex.index == nil {
+		if len(redisSearchIndex.suggestFields) > 0 {
+			return fmt.Errorf("%w: suggest tag in %s requires searchable fields", ErrEntityNotSearchable, schema.GetEntityName())
+		}
+
 		return nil
 	}
 
 	redisSearchIndex.hasFakeDelete = hsaFakeDelete
 	redisSearchIndex.hasSearchableFakeDelete = hasSearchableFakeDelete
 
+	if languageColumn != "" {
+		buildLanguageField(redisSearchIndex, languageColumn)
+	}
+
 	if err := redisSearchIndex.buildRedisSearchIndex(schema, registry); err != nil {
 		return err
 	}
 
+	if provider, ok := reflect.New(entityType).Interface().(RedisSearchSynonymsProvider); ok {
+		redisSearchIndex.index.Synonyms = provider.RedisSearchSynonyms()
+	}
+
 	if indexes := redisSearchIndicesInit[redisSearchIndex.searchCacheName]; indexes == nil {
 		redisSearchIndicesInit[redisSearchIndex.searchCacheName] = map[string]*RedisSearchIndex{}
 	}
@@ -199,13 +253,30 @@ func (p *BeeormRedisearchPlugin) PluginInterfaceEntityFlushed(
 
 	redisSetter := setter.GetRedisCacheSetter(redisSearchSchema.index.RedisPool)
 
-	key := redisSearchSchema.redisSearchPrefix + strconv.FormatUint(event.EntityID(), 10)
+	redisSearchSchema.updateSuggestions(engine, entitySchema, event)
+
+	keys := []string{redisSearchSchema.redisSearchPrefix + strconv.FormatUint(event.EntityID(), 10)}
+
+	if redisSearchSchema.index.BlueGreen {
+		active, building := cachedIndexVersionsOf(engine.GetRedis(redisSearchSchema.searchCacheName), redisSearchSchema.index)
+		keys = redisSearchSchema.index.versionedKeys(keys[0], active, building)
+	}
 
 	if event.Type() == beeorm.Delete {
-		redisSetter.Del(redisSearchSchema.searchCacheName, key)
+		redisSetter.Del(keys...)
 
 		return
 	}
 
-	redisSearchSchema.fillRedisSearchFromBind(redisSetter, event.After(), event.EntityID(), event.Type() == beeorm.Insert)
+	bind := event.After()
+
+	if event.Type() == beeorm.Update {
+		bind = redisSearchSchema.completeGeoBind(engine, entitySchema, bind, event.EntityID())
+	}
+
+	removedFields := redisSearchSchema.fillRedisSearchFromBind(redisSetter, bind, event.EntityID(), event.Type() == beeorm.Insert, keys)
+
+	if len(removedFields) > 0 {
+		redisSearchSchema.rewriteDocument(engine, entitySchema, redisSetter, event.EntityID(), keys)
+	}
 }
@@ -15,20 +15,22 @@ func (r *RedisSearchEngine) RedisSearchAggregate(
 	query *RedisSearchAggregation,
 	pager *beeorm.Pager,
 ) (result []map[string]string, totalRows uint64) {
-	schema := r.engine.GetRegistry().GetEntitySchemaForEntity(entity)
+	result, totalRows, err := r.RedisSearchAggregateE(entity, query, pager)
+	checkError(err)
 
-	options := schema.GetPluginOption(pluginCode, optionsKey)
-	if options == nil {
-		panic(fmt.Errorf("entity %s is not searchable", schema.GetEntityName()))
-	}
+	return result, totalRows
+}
 
-	redisSearchSchema, ok := options.(*tableSchemaRedisSearch)
-	if !ok {
-		panic(fmt.Errorf("entity %s is not searchable", schema.GetEntityName()))
-	}
+func (r *RedisSearchEngine) RedisSearchAggregateE(
+	entity beeorm.Entity,
+	query *RedisSearchAggregation,
+	pager *beeorm.Pager,
+) (result []map[string]string, totalRows uint64, err error) {
+	schema := r.engine.GetRegistry().GetEntitySchemaForEntity(entity)
 
-	if redisSearchSchema.index == nil {
-		panic(fmt.Errorf("entity %s is not searchable", schema.GetEntityName()))
+	redisSearchSchema, err := getRedisSearchSchema(schema)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	if query.query == nil {
@@ -39,10 +41,21 @@ func (r *RedisSearchEngine) RedisSearchAggregate(
 		query.query.hasFakeDelete = true
 	}
 
-	return r.Aggregate(redisSearchSchema.index.Name, query, pager)
+	return r.AggregateE(redisSearchSchema.index.Name, query, pager)
 }
 
 func (r *RedisSearchEngine) RedisSearchIds(entity beeorm.Entity, query *RedisSearchQuery, pager *beeorm.Pager) (ids []uint64, totalRows uint64) {
+	ids, totalRows, err := r.RedisSearchIdsE(entity, query, pager)
+	checkError(err)
+
+	return ids, totalRows
+}
+
+func (r *RedisSearchEngine) RedisSearchIdsE(
+	entity beeorm.Entity,
+	query *RedisSearchQuery,
+	pager *beeorm.Pager,
+) (ids []uint64, totalRows uint64, err error) {
 	schema := r.engine.GetRegistry().GetEntitySchemaForEntity(entity)
 
 	return redisSearchQuery(r, schema, query, pager)
@@ -54,161 +67,209 @@ func (r *RedisSearchEngine) RedisSearch(
 	entities interface{},
 	references ...string,
 ) (totalRows uint64) {
+	totalRows, err := r.RedisSearchE(query, pager, entities, references...)
+	checkError(err)
+
+	return totalRows
+}
+
+func (r *RedisSearchEngine) RedisSearchE(
+	query *RedisSearchQuery,
+	pager *beeorm.Pager,
+	entities interface{},
+	references ...string,
+) (totalRows uint64, err error) {
 	return redisSearchBase(r, entities, query, pager, references...)
 }
 
 func (r *RedisSearchEngine) RedisSearchCount(entity beeorm.Entity, query *RedisSearchQuery) (totalRows uint64) {
-	schema := r.engine.GetRegistry().GetEntitySchemaForEntity(entity)
-	_, totalRows = redisSearchQuery(r, schema, query, beeorm.NewPager(0, 0))
+	totalRows, err := r.RedisSearchCountE(entity, query)
+	checkError(err)
 
 	return totalRows
 }
 
+func (r *RedisSearchEngine) RedisSearchCountE(entity beeorm.Entity, query *RedisSearchQuery) (totalRows uint64, err error) {
+	schema := r.engine.GetRegistry().GetEntitySchemaForEntity(entity)
+	_, totalRows, err = redisSearchQuery(r, schema, query, beeorm.NewPager(0, 0))
+
+	return totalRows, err
+}
+
 func redisSearchBase(redisSearch *RedisSearchEngine,
 	entities interface{},
 	query *RedisSearchQuery,
 	pager *beeorm.Pager,
 	references ...string,
-) (totalRows uint64) {
+) (totalRows uint64, err error) {
 	elem := reflect.ValueOf(entities).Elem()
 
-	_, has, name := getEntityTypeForSlice(redisSearch.engine.GetRegistry(), elem.Type(), true)
+	_, has, name, err := getEntityTypeForSlice(redisSearch.engine.GetRegistry(), elem.Type(), true)
+	if err != nil {
+		return 0, err
+	}
+
 	if !has {
-		panic(fmt.Errorf("entity '%s' is not registered", name))
+		return 0, fmt.Errorf("%w: %s", ErrEntityNotRegistered, name)
 	}
 
 	schema := redisSearch.engine.GetRegistry().GetEntitySchema(name)
 
-	ids, total := redisSearchQuery(redisSearch, schema, query, pager)
+	ids, total, err := redisSearchQuery(redisSearch, schema, query, pager)
+	if err != nil {
+		return 0, err
+	}
+
 	if total > 0 {
 		redisSearch.engine.LoadByIDs(ids, entities, references...)
 	}
 
-	return total
+	return total, nil
 }
 
 func (r *RedisSearchEngine) RedisSearchOne(entity beeorm.Entity, query *RedisSearchQuery, references ...string) (found bool) {
+	found, err := r.RedisSearchOneE(entity, query, references...)
+	checkError(err)
+
+	return found
+}
+
+func (r *RedisSearchEngine) RedisSearchOneE(entity beeorm.Entity, query *RedisSearchQuery, references ...string) (found bool, err error) {
 	return redisSearchOne(r, entity, query, references...)
 }
 
-func redisSearchOne(redisSearch *RedisSearchEngine, entity beeorm.Entity, query *RedisSearchQuery, references ...string) (found bool) {
+func redisSearchOne(redisSearch *RedisSearchEngine, entity beeorm.Entity, query *RedisSearchQuery, references ...string) (found bool, err error) {
 	schema := redisSearch.engine.GetRegistry().GetEntitySchemaForEntity(entity)
 
-	ids, total := redisSearchQuery(redisSearch, schema, query, beeorm.NewPager(1, 1))
+	ids, total, err := redisSearchQuery(redisSearch, schema, query, beeorm.NewPager(1, 1))
+	if err != nil {
+		return false, err
+	}
+
 	if total == 0 {
-		return false
+		return false, nil
 	}
 
 	found = redisSearch.engine.LoadByID(ids[0], entity, references...)
 
-	return found
+	return found, nil
 }
 
-//nolint //cyclomatic complexity is high
-func redisSearchQuery(redisSearch *RedisSearchEngine,
-	schema beeorm.EntitySchema,
-	query *RedisSearchQuery,
-	pager *beeorm.Pager,
-) ([]uint64, uint64) {
+func getRedisSearchSchema(schema beeorm.EntitySchema) (*tableSchemaRedisSearch, error) {
 	options := schema.GetPluginOption(pluginCode, optionsKey)
 	if options == nil {
-		panic(fmt.Errorf("entity %s is not searchable", schema.GetEntityName()))
+		return nil, fmt.Errorf("%w: %s", ErrEntityNotSearchable, schema.GetEntityName())
 	}
 
 	redisSearchSchema, ok := options.(*tableSchemaRedisSearch)
-	if !ok {
-		panic(fmt.Errorf("entity %s is not searchable", schema.GetEntityName()))
+	if !ok || redisSearchSchema.index == nil {
+		return nil, fmt.Errorf("%w: %s", ErrEntityNotSearchable, schema.GetEntityName())
 	}
 
-	if redisSearchSchema.index == nil {
-		panic(fmt.Errorf("entity %s is not searchable", schema.GetEntityName()))
-	}
+	return redisSearchSchema, nil
+}
 
-	for k := range query.filtersString {
-		_, has := redisSearchSchema.columnMapping[k]
-		if !has {
-			panic(fmt.Errorf("unknown field %s", k))
-		}
+func validateRedisSearchFilter(redisSearchSchema *tableSchemaRedisSearch, field, fieldType, filterName string) error {
+	_, isGeo := redisSearchSchema.geoFields[field]
+	if _, has := redisSearchSchema.columnMapping[field]; !has && !isGeo {
+		return fmt.Errorf("%w: %s", ErrUnknownField, field)
+	}
 
-		valid := false
+	for _, indexField := range redisSearchSchema.index.Fields {
+		if indexField.Name == field {
+			if indexField.Type == fieldType {
+				return nil
+			}
 
-	MAIN:
-		for _, field := range redisSearchSchema.index.Fields {
-			if field.Name == k {
-				if field.Type == "TEXT" {
-					valid = true
+			return fmt.Errorf("%w: %s filter on field %s with type %s", ErrFilterNotAllowed, filterName, field, indexField.Type)
+		}
+	}
 
-					break MAIN
-				}
+	return fmt.Errorf("%w: %s", ErrMissingSearchableTag, field)
+}
 
-				panic(fmt.Errorf("string filter on fields %s with type %s not allowed", k, field.Type))
-			}
-		}
+func validateRedisSearchQuery(redisSearchSchema *tableSchemaRedisSearch, query *RedisSearchQuery) error {
+	if query.err != nil {
+		return query.err
+	}
 
-		if !valid {
-			panic(fmt.Errorf("missing `searchable` tag for field %s", k))
+	for k := range query.filtersString {
+		if err := validateRedisSearchFilter(redisSearchSchema, k, redisSearchIndexFieldText, "string"); err != nil {
+			return err
 		}
 	}
 
 	for k := range query.filtersNumeric {
-		_, has := redisSearchSchema.columnMapping[k]
-		if !has {
-			panic(fmt.Errorf("unknown field %s", k))
+		if err := validateRedisSearchFilter(redisSearchSchema, k, redisSearchIndexFieldNumeric, "numeric"); err != nil {
+			return err
 		}
+	}
 
-		valid := false
-	MAIN2:
-		for _, field := range redisSearchSchema.index.Fields {
-			if field.Name == k {
-				if field.Type == "NUMERIC" {
-					valid = true
-
-					break MAIN2
-				}
+	for k := range query.filtersTags {
+		if err := validateRedisSearchFilter(redisSearchSchema, k, redisSearchIndexFieldTAG, "tag"); err != nil {
+			return err
+		}
+	}
 
-				panic(fmt.Errorf("numeric filter on fields %s with type %s not allowed", k, field.Type))
-			}
+	for _, filter := range query.filtersGeoShape {
+		if err := validateRedisSearchFilter(redisSearchSchema, filter.field, redisSearchIndexFieldGeoShape, "geo shape"); err != nil {
+			return err
 		}
+	}
 
-		if !valid {
-			panic(fmt.Errorf("missing `searchable` tag for field %s", k))
+	for k := range query.filtersGeo {
+		if err := validateRedisSearchFilter(redisSearchSchema, k, redisSearchIndexFieldGeo, "geo"); err != nil {
+			return err
 		}
 	}
 
-	for k := range query.filtersTags {
-		_, has := redisSearchSchema.columnMapping[k]
-		if !has {
-			panic(fmt.Errorf("unknown field %s", k))
+	if query.knn != nil {
+		if err := validateRedisSearchFilter(redisSearchSchema, query.knn.field, redisSearchIndexFieldVector, "knn"); err != nil {
+			return err
 		}
+	}
 
-		valid := false
-	MAIN3:
-		for _, field := range redisSearchSchema.index.Fields {
-			if field.Name == k {
-				if field.Type == "TAG" {
-					valid = true
+	if query.where != nil {
+		return query.where.validate(redisSearchSchema)
+	}
 
-					break MAIN3
-				}
+	return nil
+}
 
-				panic(fmt.Errorf("tag filter on fields %s with type %s not allowed", k, field.Type))
-			}
-		}
+func redisSearchQuery(redisSearch *RedisSearchEngine,
+	schema beeorm.EntitySchema,
+	query *RedisSearchQuery,
+	pager *beeorm.Pager,
+) ([]uint64, uint64, error) {
+	redisSearchSchema, err := getRedisSearchSchema(schema)
+	if err != nil {
+		return nil, 0, err
+	}
 
-		if !valid {
-			panic(fmt.Errorf("missing `searchable` tag for field %s", k))
-		}
+	if err := validateRedisSearchQuery(redisSearchSchema, query); err != nil {
+		return nil, 0, err
 	}
 
 	query.hasFakeDelete = redisSearchSchema.hasSearchableFakeDelete
-	totalRows, res := redisSearch.search(redisSearchSchema.index.Name, query, pager, true)
+
+	totalRows, res, err := redisSearch.search(redisSearchSchema.index.Name, query, pager, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	ids := make([]uint64, len(res))
 
 	for i, v := range res {
-		ids[i], _ = strconv.ParseUint(v.(string)[redisSearchSchema.redisSearchPrefixLen:], 10, 64)
+		ids[i], _ = documentID(v.(string))
 	}
 
-	return ids, totalRows
+	return ids, totalRows, nil
+}
+
+type redisSearchGeoShapeFilter struct {
+	field     string
+	predicate string
+	param     string
 }
 
 func NewRedisSearchQuery() *RedisSearchQuery {
@@ -220,6 +281,7 @@ type RedisSearchQuery struct {
 	filtersNumeric     map[string][][]string
 	filtersNotNumeric  map[string][]string
 	filtersGeo         map[string][]interface{}
+	filtersGeoShape    []redisSearchGeoShapeFilter
 	filtersTags        map[string][][]string
 	filtersNotTags     map[string][][]string
 	filtersString      map[string][][]string
@@ -245,6 +307,17 @@ type RedisSearchQuery struct {
 	summarizeLen       int
 	withFakeDelete     bool
 	hasFakeDelete      bool
+	where              RedisSearchExpression
+	knn                *redisSearchKNN
+	params             []interface{}
+	dialect            int
+	err                error
+}
+
+type redisSearchKNN struct {
+	field string
+	k     int
+	alias string
 }
 
 func (q *RedisSearchQuery) Query(query string) *RedisSearchQuery {
@@ -437,7 +510,9 @@ func (q *RedisSearchQuery) filterString(field string, exactPhrase, not, starts b
 				}
 
 				if k == 0 {
-					panic(fmt.Errorf("search start with requires min one word with 2 characters"))
+					q.err = fmt.Errorf("%w: search start with requires min one word with 2 characters", ErrInvalidQuery)
+
+					return q
 				}
 
 				valueEscaped[i] = escaped
@@ -621,6 +696,82 @@ func (q *RedisSearchQuery) FilterGeo(field string, lon, lat, radius float64, uni
 	return q
 }
 
+// WithinPolygon matches documents with shape in field within WKT polygon
+func (q *RedisSearchQuery) WithinPolygon(field, wkt string) *RedisSearchQuery {
+	return q.filterGeoShape(field, "WITHIN", wkt)
+}
+
+// ContainsPoint matches documents with shape in field containing the point
+func (q *RedisSearchQuery) ContainsPoint(field string, lon, lat float64) *RedisSearchQuery {
+	return q.filterGeoShape(field, "CONTAINS", "POINT("+strconv.FormatFloat(lon, 'f', -1, 64)+" "+strconv.FormatFloat(lat, 'f', -1, 64)+")")
+}
+
+// Intersects matches documents with shape in field intersecting WKT shape
+func (q *RedisSearchQuery) Intersects(field, wkt string) *RedisSearchQuery {
+	return q.filterGeoShape(field, "INTERSECTS", wkt)
+}
+
+// Disjoint matches documents with shape in field which has no common point with WKT shape
+func (q *RedisSearchQuery) Disjoint(field, wkt string) *RedisSearchQuery {
+	return q.filterGeoShape(field, "DISJOINT", wkt)
+}
+
+func (q *RedisSearchQuery) filterGeoShape(field, predicate, wkt string) *RedisSearchQuery {
+	param := "shape_" + strconv.Itoa(len(q.filtersGeoShape))
+
+	q.filtersGeoShape = append(q.filtersGeoShape, redisSearchGeoShapeFilter{field: field, predicate: predicate, param: param})
+	q.Param(param, wkt)
+
+	// geo shapes need dialect 3 at least, Dialect never lowers dialect which was already set
+	return q.Dialect(3)
+}
+
+// KNN returns k nearest neighbours of vector in field, on top of other filters. Distance is returned as
+// RedisSearchResult.Distance and results are sorted by it unless other sort is set.
+func (q *RedisSearchQuery) KNN(field string, k int, vector []float32) *RedisSearchQuery {
+	return q.knnBlob(field, k, vectorToBlob(vector))
+}
+
+func (q *RedisSearchQuery) KNNFloat64(field string, k int, vector []float64) *RedisSearchQuery {
+	return q.knnBlob(field, k, vectorFloat64ToBlob(vector))
+}
+
+func (q *RedisSearchQuery) knnBlob(field string, k int, blob string) *RedisSearchQuery {
+	if k <= 0 {
+		q.err = fmt.Errorf("%w: KNN requires k greater than 0", ErrInvalidQuery)
+
+		return q
+	}
+
+	q.knn = &redisSearchKNN{field: field, k: k, alias: "__" + field + "_score"}
+	q.Param("knn_vector", blob)
+
+	return q.Dialect(2)
+}
+
+// Param sets query parameter, value of parameter with the same name is replaced
+func (q *RedisSearchQuery) Param(name string, value interface{}) *RedisSearchQuery {
+	for i := 0; i < len(q.params); i += 2 {
+		if q.params[i] == name {
+			q.params[i+1] = value
+
+			return q
+		}
+	}
+
+	q.params = append(q.params, name, value)
+
+	return q
+}
+
+func (q *RedisSearchQuery) Dialect(dialect int) *RedisSearchQuery {
+	if dialect > q.dialect {
+		q.dialect = dialect
+	}
+
+	return q
+}
+
 func (q *RedisSearchQuery) Sort(field string, desc bool) *RedisSearchQuery {
 	q.sortField = field
 	q.sortDesc = desc
@@ -748,7 +899,7 @@ func (q *RedisSearchQuery) cutDateTime(date time.Time) int64 {
 	return time.Date(date.Year(), date.Month(), date.Day(), date.Hour(), date.Minute(), date.Second(), 0, time.UTC).Unix()
 }
 
-func getEntityTypeForSlice(registry beeorm.ValidatedRegistry, sliceType reflect.Type, checkIsSlice bool) (reflect.Type, bool, string) {
+func getEntityTypeForSlice(registry beeorm.ValidatedRegistry, sliceType reflect.Type, checkIsSlice bool) (reflect.Type, bool, string, error) {
 	name := sliceType.String()
 	if name[0] == 42 {
 		name = name[1:]
@@ -757,10 +908,13 @@ func getEntityTypeForSlice(registry beeorm.ValidatedRegistry, sliceType reflect.
 	if name[0] == 91 {
 		name = name[3:]
 	} else if checkIsSlice {
-		panic(fmt.Errorf("interface %s is no slice of beeorm.Entity", sliceType.String()))
+		return nil, false, name, fmt.Errorf("%w: interface %s is no slice of beeorm.Entity", ErrEntityNotRegistered, sliceType.String())
 	}
 
 	schema := registry.GetEntitySchema(name)
+	if schema == nil {
+		return nil, false, name, nil
+	}
 
-	return schema.GetType(), true, name
+	return schema.GetType(), true, name, nil
 }
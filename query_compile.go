@@ -0,0 +1,327 @@
+package redisearch
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Compile returns FT.SEARCH arguments of the raw query for given index without executing it. LIMIT and the
+// FakeDelete filter of entity indices are added by the search methods, so they are not included. Index name and
+// INKEYS keys are returned as given, search methods add namespace prefix of the redis pool to them.
+func (q *RedisSearchQuery) Compile(index string) ([]interface{}, error) {
+	return q.compile(index, false, func(key string) string {
+		return key
+	})
+}
+
+func (q *RedisSearchQuery) String() string {
+	query, err := q.buildQueryString(false)
+	if err != nil {
+		return ""
+	}
+
+	return query
+}
+
+//nolint //Function has too many statements
+func (q *RedisSearchQuery) compile(index string, noContent bool, addNamespacePrefix func(string) string) ([]interface{}, error) {
+	args := []interface{}{"FT.SEARCH", index}
+
+	args, err := q.buildQueryArgs(args, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if noContent {
+		args = append(args, "NOCONTENT")
+	}
+
+	if q.verbatim {
+		args = append(args, "VERBATIM")
+	}
+
+	if q.noStopWords {
+		args = append(args, "NOSTOPWORDS")
+	}
+
+	if q.withScores {
+		args = append(args, "WITHSCORES")
+	}
+
+	if q.sortField != "" {
+		args = append(args, "SORTBY", q.sortField)
+
+		if q.sortDesc {
+			args = append(args, "DESC")
+		}
+	} else if q.knn != nil {
+		args = append(args, "SORTBY", q.knn.alias)
+	}
+
+	if len(q.inKeys) > 0 {
+		args = append(args, "INKEYS", len(q.inKeys))
+
+		for _, k := range q.inKeys {
+			args = append(args, addNamespacePrefix(k.(string)))
+		}
+	}
+
+	if len(q.inFields) > 0 {
+		args = append(args, "INFIELDS", len(q.inFields))
+		args = append(args, q.inFields...)
+	}
+
+	if len(q.toReturn) > 0 {
+		toReturn := q.toReturn
+
+		if q.knn != nil {
+			toReturn = append(toReturn[:len(toReturn):len(toReturn)], q.knn.alias)
+		}
+
+		args = append(args, "RETURN", len(toReturn))
+		args = append(args, toReturn...)
+	}
+
+	if q.slop != 0 {
+		slop := q.slop
+
+		if slop == -1 {
+			slop = 0
+		}
+
+		args = append(args, "SLOP", slop)
+	}
+
+	if q.inOrder {
+		args = append(args, "INORDER")
+	}
+
+	if q.lang != "" {
+		args = append(args, "LANGUAGE", q.lang)
+	}
+
+	if q.explainScore {
+		args = append(args, "EXPLAINSCORE")
+	}
+
+	if q.highlight != nil {
+		args = append(args, "HIGHLIGHT")
+
+		if l := len(q.highlight); l > 0 {
+			args = append(args, "FIELDS", l)
+			args = append(args, q.highlight...)
+		}
+
+		if q.highlightOpenTag != "" && q.highlightCloseTag != "" {
+			args = append(args, "TAGS", q.highlightOpenTag, q.highlightCloseTag)
+		}
+	}
+
+	if q.summarize != nil {
+		args = append(args, "SUMMARIZE")
+
+		if l := len(q.summarize); l > 0 {
+			args = append(args, "FIELDS", l)
+			args = append(args, q.summarize...)
+		}
+
+		if q.summarizeFrags > 0 {
+			args = append(args, "FRAGS", q.summarizeFrags)
+		}
+
+		if q.summarizeLen > 0 {
+			args = append(args, "LEN", q.summarizeLen)
+		}
+
+		if q.summarizeSeparator != "" {
+			args = append(args, "SEPARATOR", q.summarizeSeparator)
+		}
+	}
+
+	return args, nil
+}
+
+// buildQueryArgs appends query string with its parameters, geo filters are added to the query string when inlineGeo is set
+func (q *RedisSearchQuery) buildQueryArgs(args []interface{}, inlineGeo bool) ([]interface{}, error) {
+	query, err := q.buildQueryString(inlineGeo)
+	if err != nil {
+		return nil, err
+	}
+
+	args = append(args, query)
+
+	if !inlineGeo {
+		for _, field := range sortedKeys(q.filtersGeo) {
+			data := q.filtersGeo[field]
+			args = append(args, "GEOFILTER", field, data[0], data[1], data[2], data[3])
+		}
+	}
+
+	if len(q.params) > 0 {
+		args = append(args, "PARAMS", len(q.params))
+		args = append(args, q.params...)
+	}
+
+	if q.dialect > 0 {
+		args = append(args, "DIALECT", q.dialect)
+	}
+
+	return args, nil
+}
+
+func (q *RedisSearchQuery) buildQueryString(inlineGeo bool) (string, error) {
+	query, err := q.buildQuery(inlineGeo)
+	if err != nil {
+		return "", err
+	}
+
+	if q.hasFakeDelete && !q.withFakeDelete {
+		query += "-@FakeDelete:{true}"
+	}
+
+	if query == "" {
+		query = "*"
+	}
+
+	if q.knn != nil {
+		query = "(" + query + ")=>[KNN " + strconv.Itoa(q.knn.k) + " @" + q.knn.field + " $knn_vector AS " + q.knn.alias + "]"
+	}
+
+	return query, nil
+}
+
+//nolint //cyclomatic complexity is high
+func (q *RedisSearchQuery) buildQuery(inlineGeo bool) (string, error) {
+	if q.err != nil {
+		return "", q.err
+	}
+
+	query := q.query
+
+	for _, field := range sortedKeys(q.filtersNumeric) {
+		in := q.filtersNumeric[field]
+
+		if query != "" {
+			query += " "
+		}
+
+		for i, v := range in {
+			if i > 0 {
+				query += "|"
+			}
+
+			query += "@" + field + ":"
+			query += "[" + v[0] + " " + v[1] + "]"
+		}
+	}
+
+	for _, field := range sortedKeys(q.filtersTags) {
+		in := q.filtersTags[field]
+
+		for _, v := range in {
+			if query != "" {
+				query += " "
+			}
+
+			query += "@" + field + ":{ " + strings.Join(v, " | ") + " }"
+		}
+	}
+
+	for _, field := range sortedKeys(q.filtersString) {
+		in := q.filtersString[field]
+
+		for _, v := range in {
+			if query != "" {
+				query += " "
+			}
+
+			query += "@" + field + ":( " + strings.Join(v, " | ") + " )"
+		}
+	}
+
+	for _, field := range sortedKeys(q.filtersNotNumeric) {
+		in := q.filtersNotNumeric[field]
+
+		if query != "" {
+			query += " "
+		}
+
+		for _, v := range in {
+			query += "(@" + field + ":[-inf (" + v + "] | @" + field + ":[(" + v + " +inf])"
+		}
+	}
+
+	for _, field := range sortedKeys(q.filtersNotTags) {
+		in := q.filtersNotTags[field]
+
+		for _, v := range in {
+			if query != "" {
+				query += " "
+			}
+
+			query += "-@" + field + ":{ " + strings.Join(v, " | ") + " }"
+		}
+	}
+
+	for _, field := range sortedKeys(q.filtersNotString) {
+		in := q.filtersNotString[field]
+
+		for _, v := range in {
+			if query != "" {
+				query += " "
+			}
+
+			query += "-@" + field + ":( " + strings.Join(v, " | ") + " )"
+		}
+	}
+
+	if inlineGeo {
+		for _, field := range sortedKeys(q.filtersGeo) {
+			data := q.filtersGeo[field]
+
+			if query != "" {
+				query += " "
+			}
+
+			query += "@" + field + ":[" + strconv.FormatFloat(data[0].(float64), 'f', -1, 64) + " " +
+				strconv.FormatFloat(data[1].(float64), 'f', -1, 64) + " " +
+				strconv.FormatFloat(data[2].(float64), 'f', -1, 64) + " " + data[3].(string) + "]"
+		}
+	}
+
+	for _, filter := range q.filtersGeoShape {
+		if query != "" {
+			query += " "
+		}
+
+		query += "@" + filter.field + ":[" + filter.predicate + " $" + filter.param + "]"
+	}
+
+	if q.where != nil {
+		where, err := q.where.build()
+		if err != nil {
+			return "", err
+		}
+
+		if query != "" {
+			query += " "
+		}
+
+		query += "(" + where + ")"
+	}
+
+	return query, nil
+}
+
+func sortedKeys[T any](data map[string]T) []string {
+	keys := make([]string, 0, len(data))
+
+	for key := range data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
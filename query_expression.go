@@ -0,0 +1,122 @@
+package redisearch
+
+import (
+	"fmt"
+	"strings"
+)
+
+type RedisSearchExpression interface {
+	build() (string, error)
+	validate(redisSearchSchema *tableSchemaRedisSearch) error
+}
+
+type redisSearchExpressionGroup struct {
+	operator    string
+	expressions []RedisSearchExpression
+}
+
+type redisSearchExpressionNot struct {
+	expression RedisSearchExpression
+}
+
+func NewRedisSearchAnd(expression ...RedisSearchExpression) RedisSearchExpression {
+	return &redisSearchExpressionGroup{operator: " ", expressions: expression}
+}
+
+func NewRedisSearchOr(expression ...RedisSearchExpression) RedisSearchExpression {
+	return &redisSearchExpressionGroup{operator: " | ", expressions: expression}
+}
+
+func NewRedisSearchNot(expression RedisSearchExpression) RedisSearchExpression {
+	return &redisSearchExpressionNot{expression: expression}
+}
+
+func (q *RedisSearchQuery) Where(expression RedisSearchExpression) *RedisSearchQuery {
+	if q.where == nil {
+		q.where = expression
+	} else {
+		q.where = NewRedisSearchAnd(q.where, expression)
+	}
+
+	return q
+}
+
+func (g *redisSearchExpressionGroup) build() (string, error) {
+	if len(g.expressions) == 0 {
+		return "", fmt.Errorf("%w: empty expression group", ErrInvalidQuery)
+	}
+
+	parts := make([]string, len(g.expressions))
+
+	for i, expression := range g.expressions {
+		if expression == nil {
+			return "", fmt.Errorf("%w: nil expression", ErrInvalidQuery)
+		}
+
+		part, err := expression.build()
+		if err != nil {
+			return "", err
+		}
+
+		parts[i] = "(" + part + ")"
+	}
+
+	return strings.Join(parts, g.operator), nil
+}
+
+func (g *redisSearchExpressionGroup) validate(redisSearchSchema *tableSchemaRedisSearch) error {
+	for _, expression := range g.expressions {
+		if expression == nil {
+			return fmt.Errorf("%w: nil expression", ErrInvalidQuery)
+		}
+
+		if err := expression.validate(redisSearchSchema); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *redisSearchExpressionNot) build() (string, error) {
+	if n.expression == nil {
+		return "", fmt.Errorf("%w: nil expression", ErrInvalidQuery)
+	}
+
+	part, err := n.expression.build()
+	if err != nil {
+		return "", err
+	}
+
+	return "-(" + part + ")", nil
+}
+
+func (n *redisSearchExpressionNot) validate(redisSearchSchema *tableSchemaRedisSearch) error {
+	if n.expression == nil {
+		return fmt.Errorf("%w: nil expression", ErrInvalidQuery)
+	}
+
+	return n.expression.validate(redisSearchSchema)
+}
+
+func (q *RedisSearchQuery) build() (string, error) {
+	// parameters of expression are not passed to the parent query, so they would be missing in PARAMS
+	if len(q.params) > 0 || q.dialect > 0 || q.knn != nil {
+		return "", fmt.Errorf("%w: expression with params, dialect or KNN", ErrInvalidQuery)
+	}
+
+	query, err := q.buildQuery(true)
+	if err != nil {
+		return "", err
+	}
+
+	if query == "" {
+		return "", fmt.Errorf("%w: empty expression", ErrInvalidQuery)
+	}
+
+	return query, nil
+}
+
+func (q *RedisSearchQuery) validate(redisSearchSchema *tableSchemaRedisSearch) error {
+	return validateRedisSearchQuery(redisSearchSchema, q)
+}
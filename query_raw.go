@@ -1,28 +1,45 @@
 package redisearch
 
 import (
+	"errors"
 	"strconv"
-	"strings"
 
 	"github.com/latolukasz/beeorm/v2"
 )
 
 func GetEntityIDs(redisSearch *RedisSearchEngine, index string, q *RedisSearchQuery, pager *beeorm.Pager) ([]uint64, uint64) {
-	total, keys := redisSearch.SearchKeys(index, q, pager)
-	if total == 0 || len(keys) == 0 {
+	ids, total, err := GetEntityIDsE(redisSearch, index, q, pager)
+
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
 		return nil, 0
 	}
 
+	checkError(err)
+
+	return ids, total
+}
+
+func GetEntityIDsE(redisSearch *RedisSearchEngine, index string, q *RedisSearchQuery, pager *beeorm.Pager) ([]uint64, uint64, error) {
+	total, keys, err := redisSearch.SearchKeysE(index, q, pager)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if total == 0 || len(keys) == 0 {
+		return nil, 0, nil
+	}
+
 	ids := make([]uint64, len(keys))
 
 	for i, key := range keys {
-		id, err := strconv.ParseUint(strings.Split(key, ":")[1], 10, 64)
+		id, err := documentID(key)
 		if err != nil {
-			return nil, 0
+			return nil, 0, err
 		}
 
 		ids[i] = id
 	}
 
-	return ids, total
+	return ids, total, nil
 }
@@ -2,6 +2,7 @@ package redisearch
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
@@ -10,7 +11,6 @@ import (
 	"time"
 
 	"github.com/latolukasz/beeorm/v2"
-	"github.com/pkg/errors"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -18,12 +18,22 @@ const (
 	RedisSearchNullNumber     = -math.MaxInt64
 	RedisSearchIndexerChannel = "orm-redis-search-channel"
 
-	redisSearchIndexFieldText    = "TEXT"
-	redisSearchIndexFieldNumeric = "NUMERIC"
-	redisSearchIndexFieldGeo     = "GEO"
-	redisSearchIndexFieldTAG     = "TAG"
+	redisSearchIndexFieldText     = "TEXT"
+	redisSearchIndexFieldNumeric  = "NUMERIC"
+	redisSearchIndexFieldGeo      = "GEO"
+	redisSearchIndexFieldGeoShape = "GEOSHAPE"
+	redisSearchIndexFieldTAG      = "TAG"
+	redisSearchIndexFieldVector   = "VECTOR"
+
+	redisSearchPhoneticUnknown = "dm"
 
 	redisSearchForceIndexLastIDKeyPrefix = "_orm_force_index"
+	redisSearchForceIndexFieldsKeyPrefix = "_orm_force_index_fields"
+
+	RedisSearchIndexAlterStrategyRebuild   = "rebuild"
+	RedisSearchIndexAlterStrategyAddFields = "add fields"
+	RedisSearchIndexAlterStrategySynonyms  = "synonyms"
+	RedisSearchIndexAlterStrategyDrop      = "drop"
 )
 
 var redisSearchIndicesInit = make(map[string]map[string]*RedisSearchIndex)
@@ -35,6 +45,7 @@ type RedisSearchEngine struct {
 	redis              beeorm.RedisCache
 	engine             beeorm.Engine
 	redisSearchIndices map[string]*RedisSearchIndex
+	reindexThrottle    *RedisSearchReindexThrottle
 }
 
 func NewRedisSearch(ctx context.Context, engine beeorm.Engine, pool string) *RedisSearchEngine {
@@ -57,36 +68,146 @@ func NewRedisSearch(ctx context.Context, engine beeorm.Engine, pool string) *Red
 	return redisSearchInstance
 }
 
+// WithContext returns a copy of the engine whose Redisearch commands are bound to ctx
+func (r *RedisSearchEngine) WithContext(ctx context.Context) *RedisSearchEngine {
+	clone := *r
+	clone.ctx = ctx
+
+	return &clone
+}
+
+func (r *RedisSearchEngine) Context() context.Context {
+	return r.ctx
+}
+
 func (r *RedisSearchEngine) GetRedisSearchIndex(indexName string) *RedisSearchIndex {
 	return r.redisSearchIndices[indexName]
 }
 
 func (r *RedisSearchEngine) ForceReindex(index string) {
+	checkError(r.ForceReindexE(index))
+}
+
+func (r *RedisSearchEngine) ForceReindexE(index string) error {
+	return r.ForceReindexPartitionedE(index, 1)
+}
+
+// ForceReindexPartitioned splits ID space into ranges, every range is published as separate indexer event
+func (r *RedisSearchEngine) ForceReindexPartitioned(index string, partitions int) {
+	checkError(r.ForceReindexPartitionedE(index, partitions))
+}
+
+func (r *RedisSearchEngine) ForceReindexPartitionedE(index string, partitions int) error {
 	def, has := r.redisSearchIndices[index]
 	if !has {
-		panic(errors.Errorf("unknown index %s in pool %s", index, r.pool))
+		return fmt.Errorf("%w: %s in pool %s", ErrUnknownIndex, index, r.pool)
+	}
+
+	if def.BlueGreen {
+		if err := r.forceReindexBlueGreenE(def); err != nil {
+			return err
+		}
+	} else {
+		if err := r.dropIndexE(index, true); err != nil {
+			return err
+		}
+
+		if err := r.createIndexE(def); err != nil {
+			return err
+		}
+	}
+
+	r.redis.Del(redisSearchForceIndexFieldsKeyPrefix + index)
+
+	if partitions <= 1 || def.MaxID == nil {
+		event := IndexerEventRedisearch{Index: index}
+
+		r.engine.GetEventBroker().Publish(RedisSearchIndexerChannel, event, nil)
+
+		return nil
 	}
 
-	r.dropIndex(index, true)
-	r.createIndex(def)
+	events := partitionIndexerEvents(index, def.MaxID(r.engine), partitions)
 
-	event := IndexerEventRedisearch{Index: index}
+	r.reindexPartitionsStarted(index, events)
 
-	r.engine.GetEventBroker().Publish(RedisSearchIndexerChannel, event, nil)
+	// suggestion dictionaries are filled again by all ranges, so they are cleared before any range starts
+	if len(def.dictionaries) > 0 {
+		// Del adds namespace prefix to passed keys in place
+		r.redis.Del(append([]string(nil), def.dictionaries...)...)
+	}
+
+	for _, event := range events {
+		r.redis.Del(redisSearchForceIndexLastIDKeyPrefix + index + ":" + strconv.Itoa(event.Partition))
+		r.engine.GetEventBroker().Publish(RedisSearchIndexerChannel, event, nil)
+	}
+
+	return nil
+}
+
+// partitionIndexerEvents splits IDs into ranges (From, To], last range has no upper limit so new rows are indexed too
+func partitionIndexerEvents(index string, maxID uint64, partitions int) []IndexerEventRedisearch {
+	size := maxID / uint64(partitions)
+	if maxID%uint64(partitions) != 0 {
+		size++
+	}
+
+	if size == 0 {
+		size = 1
+	}
+
+	events := make([]IndexerEventRedisearch, partitions)
+
+	for i := range events {
+		events[i] = IndexerEventRedisearch{Index: index, Partition: i, Partitions: partitions, From: uint64(i) * size, To: uint64(i+1) * size}
+	}
+
+	events[partitions-1].To = 0
+
+	return events
 }
 
 func (r *RedisSearchEngine) SearchRaw(index string, query *RedisSearchQuery, pager *beeorm.Pager) (total uint64, rows []interface{}) {
+	total, rows, err := r.SearchRawE(index, query, pager)
+	checkError(err)
+
+	return total, rows
+}
+
+func (r *RedisSearchEngine) SearchRawE(index string, query *RedisSearchQuery, pager *beeorm.Pager) (total uint64, rows []interface{}, err error) {
 	return r.search(index, query, pager, false)
 }
 
 func (r *RedisSearchEngine) SearchCount(index string, query *RedisSearchQuery) uint64 {
-	total, _ := r.search(index, query, beeorm.NewPager(0, 0), false)
+	total, err := r.SearchCountE(index, query)
+	checkError(err)
 
 	return total
 }
 
+func (r *RedisSearchEngine) SearchCountE(index string, query *RedisSearchQuery) (uint64, error) {
+	total, _, err := r.search(index, query, beeorm.NewPager(0, 0), false)
+
+	return total, err
+}
+
 func (r *RedisSearchEngine) SearchResult(index string, query *RedisSearchQuery, pager *beeorm.Pager) (total uint64, rows []*RedisSearchResult) {
-	total, data := r.search(index, query, pager, false)
+	total, rows, err := r.SearchResultE(index, query, pager)
+	checkError(err)
+
+	return total, rows
+}
+
+func (r *RedisSearchEngine) SearchResultE(
+	index string,
+	query *RedisSearchQuery,
+	pager *beeorm.Pager,
+) (total uint64, rows []*RedisSearchResult, err error) {
+	total, data, err := r.search(index, query, pager, false)
+	if err != nil {
+		return 0, nil, err
+	}
+
 	rows = make([]*RedisSearchResult, 0)
 	max := len(data) - 1
 	i := 0
@@ -111,23 +232,45 @@ func (r *RedisSearchEngine) SearchResult(index string, query *RedisSearchQuery,
 		i++
 
 		row.Fields = data[i].([]interface{})
+
+		if query.knn != nil {
+			for k := 0; k < len(row.Fields)-1; k += 2 {
+				if row.Fields[k] == query.knn.alias {
+					row.Distance, _ = strconv.ParseFloat(row.Fields[k+1].(string), 64)
+
+					break
+				}
+			}
+		}
+
 		rows = append(rows, row)
 
 		i++
 	}
 
-	return total, rows
+	return total, rows, nil
 }
 
 func (r *RedisSearchEngine) SearchKeys(index string, query *RedisSearchQuery, pager *beeorm.Pager) (total uint64, keys []string) {
-	total, rows := r.search(index, query, pager, true)
+	total, keys, err := r.SearchKeysE(index, query, pager)
+	checkError(err)
+
+	return total, keys
+}
+
+func (r *RedisSearchEngine) SearchKeysE(index string, query *RedisSearchQuery, pager *beeorm.Pager) (total uint64, keys []string, err error) {
+	total, rows, err := r.search(index, query, pager, true)
+	if err != nil {
+		return 0, nil, err
+	}
+
 	keys = make([]string, len(rows))
 
 	for k, v := range rows {
 		keys[k] = r.redis.RemoveNamespacePrefix(v.(string))
 	}
 
-	return total, keys
+	return total, keys, nil
 }
 
 func (r *RedisSearchEngine) Aggregate(
@@ -135,39 +278,54 @@ func (r *RedisSearchEngine) Aggregate(
 	query *RedisSearchAggregation,
 	pager *beeorm.Pager,
 ) (result []map[string]string, totalRows uint64) {
-	if query.query == nil {
-		query.query = NewRedisSearchQuery()
-	}
-
-	index = r.redis.AddNamespacePrefix(index)
-	args := []interface{}{"FT.AGGREGATE", index}
-	args = r.buildQueryArgs(query.query, args)
-	args = append(args, query.args...)
-	args = r.applyPager(pager, args)
-	cmd := redis.NewSliceCmd(r.ctx, args...)
+	result, totalRows, err := r.AggregateE(index, query, pager)
+	checkError(err)
 
-	hasRedisLogger, redisLogger := r.engine.HasRedisLogger()
+	return result, totalRows
+}
 
-	start := getNow(hasRedisLogger)
-	err := r.redis.Process(r.ctx, cmd)
+func (r *RedisSearchEngine) AggregateE(
+	index string,
+	query *RedisSearchAggregation,
+	pager *beeorm.Pager,
+) (result []map[string]string, totalRows uint64, err error) {
+	args, err := query.compile(r.redis.AddNamespacePrefix(index))
+	if err != nil {
+		return nil, 0, err
+	}
 
-	if hasRedisLogger {
-		r.fillLogFields(redisLogger, "FT.AGGREGATE", cmd.String(), start, err)
+	args, err = r.applyPager(pager, args)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	checkError(err)
+	cmd := redis.NewSliceCmd(r.ctx, args...)
+
+	if err = r.process(cmd, "FT.AGGREGATE", ""); err != nil {
+		return nil, 0, err
+	}
 
 	res, err := cmd.Result()
-	checkError(err)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	if len(res) == 1 {
-		panic("redisearch aggregate timeout")
+		return nil, 0, fmt.Errorf("%w: redisearch aggregate timeout", ErrTimeout)
 	}
 
 	totalRows = uint64(res[0].(int64))
 	result = make([]map[string]string, totalRows)
 
-	for i, row := range res[1:] {
+	copy(result, parseAggregateRows(res[1:]))
+
+	return result, totalRows, nil
+}
+
+func parseAggregateRows(rows []interface{}) []map[string]string {
+	result := make([]map[string]string, len(rows))
+
+	for i, row := range rows {
 		data := make(map[string]string)
 		rowSlice := row.([]interface{})
 
@@ -190,249 +348,70 @@ func (r *RedisSearchEngine) Aggregate(
 		result[i] = data
 	}
 
-	return result, totalRows
+	return result
 }
 
-func (r *RedisSearchEngine) applyPager(pager *beeorm.Pager, args []interface{}) []interface{} {
+func (r *RedisSearchEngine) applyPager(pager *beeorm.Pager, args []interface{}) ([]interface{}, error) {
 	if pager != nil {
 		if pager.PageSize > 10000 {
-			panic(fmt.Errorf("pager size exceeded limit 10000"))
+			return nil, fmt.Errorf("%w: pager size %d is over 10000", ErrLimitExceeded, pager.PageSize)
 		}
 
 		args = append(args, "LIMIT")
 		args = append(args, (pager.CurrentPage-1)*pager.PageSize)
 		args = append(args, pager.PageSize)
 	} else {
-		panic(fmt.Errorf("missing pager in redis search query"))
+		return nil, ErrMissingPager
 	}
 
-	return args
+	return args, nil
 }
 
 func (r *RedisSearchEngine) GetPoolConfig() beeorm.RedisPoolConfig {
 	return r.redis.GetPoolConfig()
 }
 
-//nolint //Function has too many statements
-func (r *RedisSearchEngine) search(index string, query *RedisSearchQuery, pager *beeorm.Pager, noContent bool) (total uint64, rows []interface{}) {
-	index = r.redis.AddNamespacePrefix(index)
-	args := []interface{}{"FT.SEARCH", index}
-	args = r.buildQueryArgs(query, args)
-
-	if noContent {
-		args = append(args, "NOCONTENT")
-	}
-
-	if query.verbatim {
-		args = append(args, "VERBATIM")
-	}
-
-	if query.noStopWords {
-		args = append(args, "NOSTOPWORDS")
-	}
-
-	if query.withScores {
-		args = append(args, "WITHSCORES")
-	}
-
-	if query.sortField != "" {
-		args = append(args, "SORTBY", query.sortField)
-
-		if query.sortDesc {
-			args = append(args, "DESC")
-		}
-	}
-
-	if len(query.inKeys) > 0 {
-		args = append(args, "INKEYS", len(query.inKeys))
-
-		for _, k := range query.inKeys {
-			args = append(args, r.redis.AddNamespacePrefix(k.(string)))
-		}
-	}
-
-	if len(query.inFields) > 0 {
-		args = append(args, "INFIELDS", len(query.inFields))
-		args = append(args, query.inFields...)
-	}
-
-	if len(query.toReturn) > 0 {
-		args = append(args, "RETURN", len(query.toReturn))
-		args = append(args, query.toReturn...)
-	}
-
-	if query.slop != 0 {
-		slop := query.slop
-
-		if slop == -1 {
-			slop = 0
-		}
-
-		args = append(args, "SLOP", slop)
-	}
-
-	if query.inOrder {
-		args = append(args, "INORDER")
-	}
-
-	if query.lang != "" {
-		args = append(args, "LANGUAGE", query.lang)
-	}
-
-	if query.explainScore {
-		args = append(args, "EXPLAINSCORE")
-	}
-
-	if query.highlight != nil {
-		args = append(args, "HIGHLIGHT")
-
-		if l := len(query.highlight); l > 0 {
-			args = append(args, "FIELDS", l)
-			args = append(args, query.highlight...)
-		}
-
-		if query.highlightOpenTag != "" && query.highlightCloseTag != "" {
-			args = append(args, "TAGS", query.highlightOpenTag, query.highlightCloseTag)
-		}
+func (r *RedisSearchEngine) search(index string, query *RedisSearchQuery, pager *beeorm.Pager, noContent bool) (total uint64, rows []interface{}, err error) {
+	args, err := query.compile(r.redis.AddNamespacePrefix(index), noContent, r.redis.AddNamespacePrefix)
+	if err != nil {
+		return 0, nil, err
 	}
 
-	if query.summarize != nil {
-		args = append(args, "SUMMARIZE")
-
-		if l := len(query.summarize); l > 0 {
-			args = append(args, "FIELDS", l)
-			args = append(args, query.summarize...)
-		}
-
-		if query.summarizeFrags > 0 {
-			args = append(args, "FRAGS", query.summarizeFrags)
-		}
-
-		if query.summarizeLen > 0 {
-			args = append(args, "LEN", query.summarizeLen)
-		}
-
-		if query.summarizeSeparator != "" {
-			args = append(args, "SEPARATOR", query.summarizeSeparator)
-		}
+	args, err = r.applyPager(pager, args)
+	if err != nil {
+		return 0, nil, err
 	}
 
-	args = r.applyPager(pager, args)
 	cmd := redis.NewSliceCmd(r.ctx, args...)
-	hasRedisLogger, redisLogger := r.engine.HasRedisLogger()
-
-	start := getNow(hasRedisLogger)
-	err := r.redis.Process(r.ctx, cmd)
 
-	if hasRedisLogger {
-		r.fillLogFields(redisLogger, "FT.SEARCH", cmd.String(), start, err)
+	if err = r.process(cmd, "FT.SEARCH", ""); err != nil {
+		return 0, nil, err
 	}
 
-	checkError(err)
-
 	res, err := cmd.Result()
-	checkError(err)
-
-	total = uint64(res[0].(int64))
-
-	return total, res[1:]
-}
-
-//nolint //cyclomatic complexity is high
-func (r *RedisSearchEngine) buildQueryArgs(query *RedisSearchQuery, args []interface{}) []interface{} {
-	q := query.query
-
-	for field, in := range query.filtersNumeric {
-		if q != "" {
-			q += " "
-		}
-
-		for i, v := range in {
-			if i > 0 {
-				q += "|"
-			}
-
-			q += "@" + field + ":"
-			q += "[" + v[0] + " " + v[1] + "]"
-		}
-	}
-
-	for field, in := range query.filtersTags {
-		for _, v := range in {
-			if q != "" {
-				q += " "
-			}
-
-			q += "@" + field + ":{ " + strings.Join(v, " | ") + " }"
-		}
-	}
-
-	for field, in := range query.filtersString {
-		for _, v := range in {
-			if q != "" {
-				q += " "
-			}
-
-			q += "@" + field + ":( " + strings.Join(v, " | ") + " )"
-		}
-	}
-
-	for field, in := range query.filtersNotNumeric {
-		if q != "" {
-			q += " "
-		}
-
-		for _, v := range in {
-			q += "(@" + field + ":[-inf (" + v + "] | @" + field + ":[(" + v + " +inf])"
-		}
-	}
-
-	for field, in := range query.filtersNotTags {
-		for _, v := range in {
-			if q != "" {
-				q += " "
-			}
-
-			q += "-@" + field + ":{ " + strings.Join(v, " | ") + " }"
-		}
-	}
-
-	for field, in := range query.filtersNotString {
-		for _, v := range in {
-			if q != "" {
-				q += " "
-			}
-
-			q += "-@" + field + ":( " + strings.Join(v, " | ") + " )"
-		}
-	}
-
-	if query.hasFakeDelete && !query.withFakeDelete {
-		q += "-@FakeDelete:{true}"
-	}
-
-	if q == "" {
-		q = "*"
+	if err != nil {
+		return 0, nil, err
 	}
 
-	args = append(args, q)
-
-	for field, data := range query.filtersGeo {
-		args = append(args, "GEOFILTER", field, data[0], data[1], data[2], data[3])
-	}
+	total = uint64(res[0].(int64))
 
-	return args
+	return total, res[1:], nil
 }
 
 //nolint //cyclomatic complexity is high
-func (r *RedisSearchEngine) createIndexArgs(index *RedisSearchIndex, indexName string) []interface{} {
+func (r *RedisSearchEngine) createIndexArgs(index *RedisSearchIndex, indexName string) ([]interface{}, error) {
 	indexName = r.redis.AddNamespacePrefix(indexName)
 
 	if len(index.Prefixes) == 0 {
-		panic(errors.New("missing redis search prefix"))
+		return nil, ErrMissingPrefix
+	}
+
+	keyType := index.KeyType
+	if keyType == "" {
+		keyType = RedisSearchIndexKeyTypeHash
 	}
 
-	args := []interface{}{"FT.CREATE", indexName, "ON", "HASH", "PREFIX", len(index.Prefixes)}
+	args := []interface{}{"FT.CREATE", indexName, "ON", keyType, "PREFIX", len(index.Prefixes)}
 
 	for _, prefix := range index.Prefixes {
 		args = append(args, r.redis.AddNamespacePrefix(prefix))
@@ -489,69 +468,105 @@ func (r *RedisSearchEngine) createIndexArgs(index *RedisSearchIndex, indexName s
 	args = append(args, "SCHEMA")
 
 	for _, field := range index.Fields {
-		fieldArgs := []interface{}{field.Name, field.Type}
+		args = append(args, createIndexFieldArgs(field)...)
+	}
 
-		if field.Type == redisSearchIndexFieldText {
-			if field.NoStem {
-				fieldArgs = append(fieldArgs, "NOSTEM")
-			}
+	return args, nil
+}
 
-			if field.Weight != 1 {
-				fieldArgs = append(fieldArgs, "WEIGHT", field.Weight)
-			}
-		} else if field.Type == redisSearchIndexFieldTAG {
-			if field.TagSeparator != "" && field.TagSeparator != ", " {
-				fieldArgs = append(fieldArgs, "SEPARATOR", field.TagSeparator)
-			}
+func createIndexFieldArgs(field RedisSearchIndexField) []interface{} {
+	fieldArgs := []interface{}{field.Name, field.Type}
+
+	if field.Path != "" {
+		fieldArgs = []interface{}{field.Path, "AS", field.Name, field.Type}
+	}
+
+	if field.Type == redisSearchIndexFieldText {
+		if field.NoStem {
+			fieldArgs = append(fieldArgs, "NOSTEM")
 		}
 
-		if field.Sortable {
-			fieldArgs = append(fieldArgs, "SORTABLE")
+		if field.Weight != 1 {
+			fieldArgs = append(fieldArgs, "WEIGHT", field.Weight)
 		}
 
-		if field.NoIndex {
-			fieldArgs = append(fieldArgs, "NOINDEX")
+		if field.Phonetic != "" {
+			fieldArgs = append(fieldArgs, "PHONETIC", field.Phonetic)
+		}
+
+		if field.WithSuffixTrie {
+			fieldArgs = append(fieldArgs, "WITHSUFFIXTRIE")
+		}
+	} else if field.Type == redisSearchIndexFieldTAG {
+		if field.TagSeparator != "" && field.TagSeparator != ", " {
+			fieldArgs = append(fieldArgs, "SEPARATOR", field.TagSeparator)
+		}
+
+		if field.WithSuffixTrie {
+			fieldArgs = append(fieldArgs, "WITHSUFFIXTRIE")
+		}
+	} else if field.Type == redisSearchIndexFieldGeoShape {
+		if field.CoordSystem != "" {
+			fieldArgs = append(fieldArgs, field.CoordSystem)
+		}
+	} else if field.Type == redisSearchIndexFieldVector {
+		vectorType := field.VectorType
+		if vectorType == "" {
+			vectorType = RedisSearchVectorTypeFloat32
 		}
 
-		args = append(args, fieldArgs...)
+		fieldArgs = append(fieldArgs, field.VectorAlgorithm, 6, "TYPE", vectorType, "DIM", field.VectorDim,
+			"DISTANCE_METRIC", field.VectorDistanceMetric)
 	}
 
-	return args
-}
+	if field.Sortable {
+		fieldArgs = append(fieldArgs, "SORTABLE")
 
-func (r *RedisSearchEngine) createIndex(index *RedisSearchIndex) {
-	args := r.createIndexArgs(index, index.Name)
-	cmd := redis.NewStringCmd(r.ctx, args...)
+		if field.UNF && (field.Type == redisSearchIndexFieldText || field.Type == redisSearchIndexFieldTAG) {
+			fieldArgs = append(fieldArgs, "UNF")
+		}
+	}
 
-	hasRedisLogger, redisLogger := r.engine.HasRedisLogger()
+	if field.NoIndex {
+		fieldArgs = append(fieldArgs, "NOINDEX")
+	}
 
-	start := getNow(hasRedisLogger)
+	return fieldArgs
+}
 
-	err := r.redis.Process(r.ctx, cmd)
-	if hasRedisLogger {
-		r.fillLogFields(redisLogger, "FT.CREATE", cmd.String(), start, err)
+func (r *RedisSearchEngine) createIndexE(index *RedisSearchIndex) error {
+	args, err := r.createIndexArgs(index, index.Name)
+	if err != nil {
+		return err
 	}
 
-	checkError(err)
+	cmd := redis.NewStringCmd(r.ctx, args...)
+
+	if err = r.process(cmd, "FT.CREATE", ""); err != nil {
+		return err
+	}
+
+	return r.updateSynonymsE(index, index.SkipInitialScan)
 }
 
 func (r *RedisSearchEngine) ListIndices() []string {
-	cmd := redis.NewStringSliceCmd(r.ctx, "FT._LIST")
-
-	hasRedisLogger, redisLogger := r.engine.HasRedisLogger()
+	indices, err := r.ListIndicesE()
+	checkError(err)
 
-	start := getNow(hasRedisLogger)
+	return indices
+}
 
-	err := r.redis.Process(r.ctx, cmd)
+func (r *RedisSearchEngine) ListIndicesE() ([]string, error) {
+	cmd := redis.NewStringSliceCmd(r.ctx, "FT._LIST")
 
-	if hasRedisLogger {
-		r.fillLogFields(redisLogger, "FT.LIST", "FT.LIST", start, err)
+	if err := r.process(cmd, "FT.LIST", "FT.LIST"); err != nil {
+		return nil, err
 	}
 
-	checkError(err)
-
 	res, err := cmd.Result()
-	checkError(err)
+	if err != nil {
+		return nil, err
+	}
 
 	if r.redis.HasNamespace() {
 		finalResult := make([]string, 0)
@@ -563,13 +578,13 @@ func (r *RedisSearchEngine) ListIndices() []string {
 			}
 		}
 
-		return finalResult
+		return finalResult, nil
 	}
 
-	return res
+	return res, nil
 }
 
-func (r *RedisSearchEngine) dropIndex(indexName string, withHashes bool) {
+func (r *RedisSearchEngine) dropIndexE(indexName string, withHashes bool) error {
 	indexName = r.redis.AddNamespacePrefix(indexName)
 	args := []interface{}{"FT.DROPINDEX", indexName}
 
@@ -579,56 +594,39 @@ func (r *RedisSearchEngine) dropIndex(indexName string, withHashes bool) {
 
 	cmd := redis.NewStringCmd(r.ctx, args...)
 
-	hasRedisLogger, redisLogger := r.engine.HasRedisLogger()
-
-	start := getNow(hasRedisLogger)
-
-	err := r.redis.Process(r.ctx, cmd)
-
-	if hasRedisLogger {
-		r.fillLogFields(redisLogger, "FT.DROPINDEX", cmd.String(), start, err)
+	err := r.process(cmd, "FT.DROPINDEX", "")
+	if errors.Is(err, ErrUnknownIndex) {
+		return nil
 	}
 
-	if err != nil && strings.HasPrefix(err.Error(), "Unknown Index ") {
-		return
-	}
+	return err
+}
 
+func (r *RedisSearchEngine) Info(indexName string) *RedisSearchIndexInfo {
+	info, err := r.InfoE(indexName)
 	checkError(err)
 
-	_, err = cmd.Result()
-	checkError(err)
+	return info
 }
 
 //nolint //Function has too many statements
-func (r *RedisSearchEngine) Info(indexName string) *RedisSearchIndexInfo {
+func (r *RedisSearchEngine) InfoE(indexName string) (*RedisSearchIndexInfo, error) {
 	indexName = r.redis.AddNamespacePrefix(indexName)
 	cmd := redis.NewSliceCmd(r.ctx, "FT.INFO", indexName)
 
-	hasRedisLogger, redisLogger := r.engine.HasRedisLogger()
-
-	start := getNow(hasRedisLogger)
-
-	err := r.redis.Process(r.ctx, cmd)
-
-	has := true
-
-	if err != nil && err.Error() == "Unknown Index name" {
-		err = nil
-		has = false
-	}
-
-	if hasRedisLogger {
-		r.fillLogFields(redisLogger, "FT.INFO", "FT.INFO "+indexName, start, err)
+	err := r.process(cmd, "FT.INFO", "FT.INFO "+indexName)
+	if errors.Is(err, ErrUnknownIndex) {
+		return nil, nil
 	}
 
-	if !has {
-		return nil
+	if err != nil {
+		return nil, err
 	}
 
-	checkError(err)
 	res, err := cmd.Result()
-
-	checkError(err)
+	if err != nil {
+		return nil, err
+	}
 
 	info := &RedisSearchIndexInfo{}
 
@@ -652,6 +650,8 @@ func (r *RedisSearchEngine) Info(indexName string) *RedisSearchIndexInfo {
 					options.NoFields = true
 				case "NOOFFSETS":
 					options.NoOffsets = true
+				case "NOHL":
+					options.NoNHL = true
 				case "MAXTEXTFIELDS":
 					options.MaxTextFields = true
 				}
@@ -677,6 +677,8 @@ func (r *RedisSearchEngine) Info(indexName string) *RedisSearchIndexInfo {
 					definition.Prefixes = prefixes
 				case "language_field":
 					definition.LanguageField = def[subKey+1].(string)
+				case "default_language":
+					definition.DefaultLanguage = def[subKey+1].(string)
 				case "default_score":
 					definition.DefaultScore = def[subKey+1].(float64)
 				case "score_field":
@@ -708,6 +710,12 @@ func (r *RedisSearchEngine) Info(indexName string) *RedisSearchIndexInfo {
 						field.NoIndex = true
 					case "SEPARATOR":
 						field.TagSeparator = def[subKey+1].(string)
+					case "PHONETIC":
+						field.Phonetic = parsePhoneticInfo(def, subKey)
+					case "WITHSUFFIXTRIE":
+						field.WithSuffixTrie = true
+					case "UNF":
+						field.UNF = true
 					}
 				}
 
@@ -722,10 +730,13 @@ func (r *RedisSearchEngine) Info(indexName string) *RedisSearchIndexInfo {
 			for i, v := range fieldsRaw {
 				def := v.([]interface{})
 				field := RedisSearchIndexInfoField{}
+				identifier := ""
 
 				for subKey, subValue := range def {
 					switch subValue {
 					case "identifier":
+						identifier = def[subKey+1].(string)
+					case "attribute":
 						field.Name = def[subKey+1].(string)
 					case "type":
 						field.Type = def[subKey+1].(string)
@@ -739,9 +750,31 @@ func (r *RedisSearchEngine) Info(indexName string) *RedisSearchIndexInfo {
 						field.NoIndex = true
 					case "SEPARATOR":
 						field.TagSeparator = def[subKey+1].(string)
+					case "PHONETIC":
+						field.Phonetic = parsePhoneticInfo(def, subKey)
+					case "WITHSUFFIXTRIE":
+						field.WithSuffixTrie = true
+					case "UNF":
+						field.UNF = true
+					case "coord_system":
+						field.CoordSystem, _ = def[subKey+1].(string)
+					case "algorithm":
+						field.VectorAlgorithm, _ = def[subKey+1].(string)
+					case "data_type":
+						field.VectorType, _ = def[subKey+1].(string)
+					case "dim":
+						field.VectorDim = int(parseReplyInt(def[subKey+1]))
+					case "distance_metric":
+						field.VectorDistanceMetric, _ = def[subKey+1].(string)
 					}
 				}
 
+				if field.Name == "" {
+					field.Name = identifier
+				} else if identifier != field.Name {
+					field.Path = identifier
+				}
+
 				fields[i] = field
 			}
 
@@ -822,28 +855,131 @@ func (r *RedisSearchEngine) Info(indexName string) *RedisSearchIndexInfo {
 		}
 	}
 
-	return info
+	return info, nil
 }
 
-func (r *RedisSearchEngine) addAlter(index *RedisSearchIndex, documents uint64, changes []string) RedisSearchIndexAlter {
-	query := fmt.Sprintf("%v", r.createIndexArgs(index, index.Name))[1:]
+func (r *RedisSearchEngine) addAlter(index *RedisSearchIndex, documents uint64, changes []string) (RedisSearchIndexAlter, error) {
+	args, err := r.createIndexArgs(index, index.Name)
+	if err != nil {
+		return RedisSearchIndexAlter{}, err
+	}
+
+	query := fmt.Sprintf("%v", args)[1:]
 	query = query[0 : len(query)-1]
-	alter := RedisSearchIndexAlter{Pool: r.redis.GetCode(), Name: index.Name, Query: query, Changes: changes, search: r}
+	alter := RedisSearchIndexAlter{
+		Pool:     r.redis.GetCode(),
+		Name:     index.Name,
+		Query:    query,
+		Changes:  changes,
+		Strategy: RedisSearchIndexAlterStrategyRebuild,
+		search:   r,
+	}
 	indexToAdd := index.Name
 	alter.Execute = func() {
 		alter.search.ForceReindex(indexToAdd)
 	}
 	alter.Documents = documents
 
+	return alter, nil
+}
+
+func (r *RedisSearchEngine) addFieldsAlter(
+	index *RedisSearchIndex,
+	indexName string,
+	documents uint64,
+	changes []string,
+	fields []RedisSearchIndexField,
+) RedisSearchIndexAlter {
+	queries := make([]string, len(fields))
+
+	for i, field := range fields {
+		args := append([]interface{}{"FT.ALTER", indexName, "SCHEMA", "ADD"}, createIndexFieldArgs(field)...)
+		query := fmt.Sprintf("%v", args)
+		queries[i] = query[1 : len(query)-1]
+	}
+
+	alter := RedisSearchIndexAlter{
+		Pool:      r.redis.GetCode(),
+		Name:      index.Name,
+		Query:     strings.Join(queries, "\n"),
+		Documents: documents,
+		Changes:   changes,
+		Strategy:  RedisSearchIndexAlterStrategyAddFields,
+		search:    r,
+	}
+	alter.Execute = func() {
+		checkError(alter.search.addFieldsE(index, indexName, fields))
+	}
+
 	return alter
 }
 
+// addFieldsE adds new fields to existing index and schedules indexer pass which fills only these fields
+func (r *RedisSearchEngine) addFieldsE(index *RedisSearchIndex, indexName string, fields []RedisSearchIndexField) error {
+	if index.BlueGreen {
+		if _, building := r.IndexVersions(index.Name); building != 0 {
+			return fmt.Errorf("%w: can't add fields to index %s while new version is built", ErrReindexInProgress, index.Name)
+		}
+	}
+
+	fieldNames := make([]string, len(fields))
+
+	for i, field := range fields {
+		args := append([]interface{}{"FT.ALTER", r.redis.AddNamespacePrefix(indexName), "SCHEMA", "ADD"}, createIndexFieldArgs(field)...)
+
+		cmd := redis.NewStatusCmd(r.ctx, args...)
+
+		if err := r.process(cmd, "FT.ALTER", ""); err != nil {
+			return err
+		}
+
+		fieldNames[i] = field.Name
+	}
+
+	fieldsKey := redisSearchForceIndexFieldsKeyPrefix + index.Name
+
+	if pending, has := r.redis.Get(fieldsKey); has && pending != "" {
+		fieldNames = append(strings.Split(pending, ","), fieldNames...)
+	}
+
+	r.redis.Set(fieldsKey, strings.Join(fieldNames, ","), 0)
+
+	r.engine.GetEventBroker().Publish(RedisSearchIndexerChannel, IndexerEventRedisearch{Index: index.Name}, nil)
+
+	return nil
+}
+
+func (r *RedisSearchEngine) process(cmd redis.Cmder, operation, query string) error {
+	hasRedisLogger, redisLogger := r.engine.HasRedisLogger()
+
+	start := getNow(hasRedisLogger)
+
+	err := r.redis.Process(r.ctx, cmd)
+
+	if hasRedisLogger {
+		if query == "" {
+			query = cmd.String()
+		}
+
+		r.fillLogFields(redisLogger, operation, query, start, err)
+	}
+
+	return parseRedisSearchError(err)
+}
+
 func (r *RedisSearchEngine) fillLogFields(handlers []beeorm.LogHandler, operation, query string, start *time.Time, err error) {
 	fillLogFields(r.engine, handlers, r.redis.GetCode(), "redis", operation, query, start, false, err)
 }
 
-//nolint //Function has too many statements
 func (r *RedisSearchEngine) GetRedisSearchAlters() (alters []RedisSearchIndexAlter) {
+	alters, err := r.GetRedisSearchAltersE()
+	checkError(err)
+
+	return alters
+}
+
+//nolint //Function has too many statements
+func (r *RedisSearchEngine) GetRedisSearchAltersE() (alters []RedisSearchIndexAlter, err error) {
 	alters = make([]RedisSearchIndexAlter, 0)
 
 	for _, pool := range r.engine.GetRegistry().GetRedisPools() {
@@ -865,7 +1001,9 @@ func (r *RedisSearchEngine) GetRedisSearchAlters() (alters []RedisSearchIndexAlt
 				for _, part := range strings.Split(line, ",") {
 					if strings.HasPrefix(part, "ver=") {
 						ver, err := strconv.ParseUint(part[4:7], 10, 64)
-						checkError(err)
+						if err != nil {
+							return nil, err
+						}
 
 						version = &ver
 
@@ -885,39 +1023,101 @@ func (r *RedisSearchEngine) GetRedisSearchAlters() (alters []RedisSearchIndexAlt
 
 		inRedis := make(map[string]bool)
 
-		for _, name := range r.ListIndices() {
+		indices, err := r.ListIndicesE()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range indices {
 			def, has := r.redisSearchIndices[name]
+			compareDef := def
+			oldVersion := false
+			buildInProgress := false
+
+			if !has {
+				if versionedDef, version := r.resolveVersionedIndex(name); versionedDef != nil {
+					oldVersion = true
+
+					active, building := r.IndexVersions(versionedDef.Name)
+
+					if version == building {
+						continue
+					}
+
+					if version == active {
+						def = versionedDef
+						compareDef = versionedDef.versioned(version)
+						has = true
+						buildInProgress = building != 0
+					}
+				}
+			}
+
+			info, err := r.InfoE(name)
+			if err != nil {
+				return nil, err
+			}
+
+			if info == nil {
+				continue
+			}
 
 			if !has {
-				alter := RedisSearchIndexAlter{Pool: poolName, Query: "FT.DROPINDEX " + name, Name: name, search: r}
+				alter := RedisSearchIndexAlter{
+					Pool:     poolName,
+					Query:    "FT.DROPINDEX " + name,
+					Name:     name,
+					Strategy: RedisSearchIndexAlterStrategyDrop,
+					search:   r,
+				}
+				if oldVersion {
+					alter.Query += " DD"
+				}
+
 				nameToRemove := name
 				alter.Execute = func() {
-					alter.search.dropIndex(nameToRemove, false)
+					checkError(alter.search.dropIndexE(nameToRemove, oldVersion))
 				}
-				alter.Documents = r.Info(name).NumDocs
+				alter.Documents = info.NumDocs
 				alters = append(alters, alter)
 
 				continue
 			}
 
-			inRedis[name] = true
-			info := r.Info(name)
+			inRedis[def.Name] = true
 			changes := make([]string, 0)
+			newFields := make([]RedisSearchIndexField, 0)
+
+			if def.BlueGreen && compareDef == def {
+				changes = append(changes, "different blue/green mode")
+			}
+
 			stopWords := def.StopWords
 
 			bothEmpty := len(info.StopWords) == 0 && len(stopWords) == 0
 
-			if !bothEmpty && !reflect.DeepEqual(info.StopWords, stopWords) {
+			// stopwords_list is reported only for indices with custom stop words
+			if (stopWords == nil) != (info.StopWords == nil) || (!bothEmpty && !reflect.DeepEqual(info.StopWords, stopWords)) {
 				changes = append(changes, "different stop words")
 			}
 
+			synonyms, err := r.SynonymsE(name)
+			if err != nil {
+				return nil, err
+			}
+
+			synonymsChanged, synonymsOnlyAdded := synonymsChanges(def.Synonyms, synonyms)
+			if synonymsChanged {
+				changes = append(changes, "different synonyms")
+			}
+
 			prefixes := make([]string, 0)
 
-			if len(def.Prefixes) == 0 || (len(def.Prefixes) == 1 && def.Prefixes[0] == "") {
+			if len(compareDef.Prefixes) == 0 || (len(compareDef.Prefixes) == 1 && compareDef.Prefixes[0] == "") {
 				prefixes = append(prefixes, r.redis.AddNamespacePrefix(""))
-				def.Prefixes = []string{""}
+				compareDef.Prefixes = []string{""}
 			} else {
-				for _, v := range def.Prefixes {
+				for _, v := range compareDef.Prefixes {
 					prefixes = append(prefixes, r.redis.AddNamespacePrefix(v))
 				}
 			}
@@ -926,6 +1126,15 @@ func (r *RedisSearchEngine) GetRedisSearchAlters() (alters []RedisSearchIndexAlt
 				changes = append(changes, "different prefixes")
 			}
 
+			keyType := def.KeyType
+			if keyType == "" {
+				keyType = RedisSearchIndexKeyTypeHash
+			}
+
+			if info.Definition.KeyType != keyType {
+				changes = append(changes, "different key type")
+			}
+
 			languageField := def.LanguageField
 			if languageField == "" && (version != nil && *version < 202) {
 				languageField = "__language"
@@ -935,6 +1144,15 @@ func (r *RedisSearchEngine) GetRedisSearchAlters() (alters []RedisSearchIndexAlt
 				changes = append(changes, "different language field")
 			}
 
+			defaultLanguage := def.DefaultLanguage
+			if defaultLanguage == "" {
+				defaultLanguage = "english"
+			}
+
+			if info.Definition.DefaultLanguage != "" && !strings.EqualFold(info.Definition.DefaultLanguage, defaultLanguage) {
+				changes = append(changes, "different default language")
+			}
+
 			scoreField := def.ScoreField
 			if scoreField == "" && (version != nil && *version < 202) {
 				scoreField = "__score"
@@ -956,6 +1174,11 @@ func (r *RedisSearchEngine) GetRedisSearchAlters() (alters []RedisSearchIndexAlt
 				changes = append(changes, "different option NOOFFSETS")
 			}
 
+			// NOOFFSETS implies NOHL
+			if !def.NoOffsets && info.Options.NoNHL != def.NoNHL {
+				changes = append(changes, "different option NOHL")
+			}
+
 			if info.Options.MaxTextFields != def.MaxTextFields {
 				changes = append(changes, "different option MAXTEXTFIELDS")
 			}
@@ -972,20 +1195,35 @@ func (r *RedisSearchEngine) GetRedisSearchAlters() (alters []RedisSearchIndexAlt
 			for _, defField := range def.Fields {
 				for _, infoField := range info.Fields {
 					if defField.Name == infoField.Name {
+						if defField.Path != infoField.Path {
+							changes = append(changes, "different field path "+infoField.Name)
+						}
+
 						if defField.Type != infoField.Type {
 							changes = append(changes, "different field type "+infoField.Name)
 						} else {
 							if defField.Type == redisSearchIndexFieldText {
-								if defField.NoStem != infoField.NoStem {
-									changes = append(changes, "different field nostem "+infoField.Name)
-								}
-								if defField.Weight != infoField.Weight {
-									changes = append(changes, "different field weight "+infoField.Name)
-								}
+								changes = append(changes, textFieldChanges(defField, infoField)...)
 							} else if defField.Type == redisSearchIndexFieldTAG {
 								if defField.TagSeparator != infoField.TagSeparator {
 									changes = append(changes, "different field separator "+infoField.Name)
 								}
+								if defField.WithSuffixTrie != infoField.WithSuffixTrie {
+									changes = append(changes, "different field suffix trie "+infoField.Name)
+								}
+								if defField.Sortable && defField.UNF != infoField.UNF {
+									changes = append(changes, "different field unf "+infoField.Name)
+								}
+							} else if defField.Type == redisSearchIndexFieldGeoShape {
+								coordSystem := defField.CoordSystem
+								if coordSystem == "" {
+									coordSystem = RedisSearchGeoShapeSpherical
+								}
+								if infoField.CoordSystem != "" && infoField.CoordSystem != coordSystem {
+									changes = append(changes, "different field coord system "+infoField.Name)
+								}
+							} else if defField.Type == redisSearchIndexFieldVector {
+								changes = append(changes, vectorFieldChanges(defField, infoField)...)
 							}
 						}
 
@@ -1001,6 +1239,7 @@ func (r *RedisSearchEngine) GetRedisSearchAlters() (alters []RedisSearchIndexAlt
 					}
 				}
 				changes = append(changes, "new field "+defField.Name)
+				newFields = append(newFields, defField)
 			}
 		MAIN2:
 			for _, infoField := range info.Fields {
@@ -1012,8 +1251,18 @@ func (r *RedisSearchEngine) GetRedisSearchAlters() (alters []RedisSearchIndexAlt
 				changes = append(changes, "unneeded field "+infoField.Name)
 			}
 
-			if len(changes) > 0 {
-				alters = append(alters, r.addAlter(def, info.NumDocs, changes))
+			if len(changes) == 1 && synonymsOnlyAdded {
+				alters = append(alters, r.addSynonymsAlter(compareDef, info.NumDocs, changes))
+			} else if len(changes) > 0 && len(changes) == len(newFields) && !buildInProgress {
+				// version being built would not get new fields, so whole index is rebuilt instead
+				alters = append(alters, r.addFieldsAlter(def, compareDef.Name, info.NumDocs, changes, newFields))
+			} else if len(changes) > 0 {
+				alter, err := r.addAlter(def, info.NumDocs, changes)
+				if err != nil {
+					return nil, err
+				}
+
+				alters = append(alters, alter)
 			}
 		}
 
@@ -1023,11 +1272,16 @@ func (r *RedisSearchEngine) GetRedisSearchAlters() (alters []RedisSearchIndexAlt
 				continue
 			}
 
-			alters = append(alters, r.addAlter(index, 0, []string{"new index"}))
+			alter, err := r.addAlter(index, 0, []string{"new index"})
+			if err != nil {
+				return nil, err
+			}
+
+			alters = append(alters, alter)
 		}
 	}
 
-	return alters
+	return alters, nil
 }
 
 type RedisSearchIndexAlter struct {
@@ -1036,6 +1290,7 @@ type RedisSearchIndexAlter struct {
 	Query     string
 	Documents uint64
 	Changes   []string
+	Strategy  string
 	Pool      string
 	Execute   func()
 }
@@ -1043,6 +1298,7 @@ type RedisSearchIndexAlter struct {
 type RedisSearchIndexInfoOptions struct {
 	NoFreqs       bool
 	NoOffsets     bool
+	NoNHL         bool
 	NoFields      bool
 	MaxTextFields bool
 }
@@ -1073,27 +1329,38 @@ type RedisSearchIndexInfo struct {
 }
 
 type RedisSearchIndexInfoDefinition struct {
-	KeyType       string
-	Prefixes      []string
-	LanguageField string
-	ScoreField    string
-	DefaultScore  float64
+	KeyType         string
+	Prefixes        []string
+	DefaultLanguage string
+	LanguageField   string
+	ScoreField      string
+	DefaultScore    float64
 }
 
 type RedisSearchIndexInfoField struct {
-	Name         string
-	Type         string
-	Weight       float64
-	Sortable     bool
-	NoStem       bool
-	NoIndex      bool
-	TagSeparator string
+	Name                 string
+	Path                 string
+	Type                 string
+	Weight               float64
+	Sortable             bool
+	NoStem               bool
+	NoIndex              bool
+	TagSeparator         string
+	Phonetic             string
+	WithSuffixTrie       bool
+	UNF                  bool
+	CoordSystem          string
+	VectorAlgorithm      string
+	VectorType           string
+	VectorDim            int
+	VectorDistanceMetric string
 }
 
 type RedisSearchResult struct {
 	Key          string
 	Fields       []interface{}
 	Score        float64
+	Distance     float64
 	ExplainScore []interface{}
 }
 
@@ -1114,6 +1381,71 @@ func (r *RedisSearchResult) Value(field string) interface{} {
 	return nil
 }
 
+func textFieldChanges(defField RedisSearchIndexField, infoField RedisSearchIndexInfoField) []string {
+	changes := make([]string, 0)
+
+	if defField.NoStem != infoField.NoStem {
+		changes = append(changes, "different field nostem "+infoField.Name)
+	}
+
+	if defField.Weight != infoField.Weight {
+		changes = append(changes, "different field weight "+infoField.Name)
+	}
+
+	// older RediSearch versions report PHONETIC flag without matcher
+	if (defField.Phonetic == "") != (infoField.Phonetic == "") ||
+		(infoField.Phonetic != redisSearchPhoneticUnknown && infoField.Phonetic != defField.Phonetic) {
+		changes = append(changes, "different field phonetic "+infoField.Name)
+	}
+
+	if defField.WithSuffixTrie != infoField.WithSuffixTrie {
+		changes = append(changes, "different field suffix trie "+infoField.Name)
+	}
+
+	if defField.Sortable && defField.UNF != infoField.UNF {
+		changes = append(changes, "different field unf "+infoField.Name)
+	}
+
+	return changes
+}
+
+func parsePhoneticInfo(def []interface{}, key int) string {
+	if key+1 < len(def) {
+		if matcher, ok := def[key+1].(string); ok && strings.HasPrefix(matcher, "dm:") {
+			return matcher
+		}
+	}
+
+	return redisSearchPhoneticUnknown
+}
+
+func vectorFieldChanges(defField RedisSearchIndexField, infoField RedisSearchIndexInfoField) []string {
+	changes := make([]string, 0)
+
+	if infoField.VectorAlgorithm != "" && infoField.VectorAlgorithm != defField.VectorAlgorithm {
+		changes = append(changes, "different field vector algorithm "+infoField.Name)
+	}
+
+	vectorType := defField.VectorType
+	if vectorType == "" {
+		vectorType = RedisSearchVectorTypeFloat32
+	}
+
+	if infoField.VectorType != "" && infoField.VectorType != vectorType {
+		changes = append(changes, "different field vector type "+infoField.Name)
+	}
+
+	if infoField.VectorDim != 0 && infoField.VectorDim != defField.VectorDim {
+		changes = append(changes, "different field vector dim "+infoField.Name)
+	}
+
+	if infoField.VectorDistanceMetric != "" && infoField.VectorDistanceMetric != defField.VectorDistanceMetric {
+		changes = append(changes, "different field vector distance metric "+infoField.Name)
+	}
+
+	return changes
+}
+
 func checkError(err error) {
 	if err != nil {
 		panic(err)
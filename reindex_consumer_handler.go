@@ -1,64 +1,166 @@
 package redisearch
 
 import (
+	"fmt"
 	"strconv"
-
-	"github.com/pkg/errors"
+	"strings"
 )
 
 // HandleRedisIndexerEvent : put this in your consumer from stream "RedisSearchIndexerChannel"
 func (r *RedisSearchEngine) HandleRedisIndexerEvent(indexName string) {
+	checkError(r.HandleRedisIndexerEventE(indexName))
+}
+
+func (r *RedisSearchEngine) HandleRedisIndexerEventE(indexName string) error {
+	return r.HandleIndexerEventE(IndexerEventRedisearch{Index: indexName})
+}
+
+// HandleIndexerEvent : same as HandleRedisIndexerEvent, but it supports events of partitioned reindex
+func (r *RedisSearchEngine) HandleIndexerEvent(event IndexerEventRedisearch) {
+	checkError(r.HandleIndexerEventE(event))
+}
+
+//nolint //Function has too many statements
+func (r *RedisSearchEngine) HandleIndexerEventE(event IndexerEventRedisearch) (err error) {
 	var indexDefinition *RedisSearchIndex
 
+	indexName := event.Index
+
 	val, has := r.redisSearchIndices[indexName]
 	if has {
 		indexDefinition = val
 	}
 
 	if indexDefinition == nil {
-		return
+		return nil
 	}
 
-	pusher := NewRedisSearchIndexPusher(r.engine, r.pool)
+	pusher := NewRedisSearchIndexPusher(r.engine, r.pool).(*redisSearchIndexPusher)
+	pusher.rewriteKey = r.keyRewriter(indexName, true)
+
+	jsonPusher := NewRedisSearchIndexJSONPusher(r.engine, r.pool).(*redisSearchIndexJSONPusher)
+	jsonPusher.rewriteKey = pusher.rewriteKey
 
-	id := uint64(0)
+	pusher.throttle = r.newReindexThrottle(indexDefinition)
+	jsonPusher.throttle = pusher.throttle
+
+	id := event.From
 	idRedisKey := redisSearchForceIndexLastIDKeyPrefix + indexName
+
+	if event.Partitions > 0 {
+		idRedisKey += ":" + strconv.Itoa(event.Partition)
+	}
+
 	idInRedis, has := r.redis.Get(idRedisKey)
 
 	if has {
 		id, _ = strconv.ParseUint(idInRedis, 10, 64)
 	}
 
+	indexer := indexDefinition.Indexer
+	fieldsKey := redisSearchForceIndexFieldsKeyPrefix + indexName
+	fields, hasFields := r.redis.Get(fieldsKey)
+
+	if hasFields && fields != "" && indexDefinition.fieldsIndexer != nil {
+		indexer = indexDefinition.fieldsIndexer(strings.Split(fields, ","))
+	} else if event.To > 0 && indexDefinition.rangeIndexer != nil {
+		indexer = indexDefinition.rangeIndexer(event.To)
+	} else if event.Partitions == 0 && id == 0 && len(indexDefinition.dictionaries) > 0 {
+		// dictionaries of partitioned reindex are cleared once in ForceReindexPartitioned
+		// Del adds namespace prefix to passed keys in place
+		r.redis.Del(append([]string(nil), indexDefinition.dictionaries...)...)
+	}
+
+	if event.Partitions == 0 {
+		r.reindexStarted(indexName, id)
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			r.reindexFinished(indexName, rec)
+			panic(rec)
+		}
+
+		if err != nil {
+			r.reindexFinished(indexName, err)
+		}
+	}()
+
+	pushed := uint64(0)
+
 	for {
 		hasMore := false
 		nextID := uint64(0)
 
-		if indexDefinition.Indexer != nil {
-			newID, hasNext := indexDefinition.Indexer(r.engine, id, pusher)
+		if indexer != nil {
+			newID, hasNext := indexer(r.engine, id, pusher)
 			hasMore = hasNext
 			nextID = newID
 
 			pusher.Flush()
 
-			if hasMore {
-				r.redis.Set(idRedisKey, strconv.FormatUint(nextID, 10), 86400)
+			if pusher.err != nil {
+				return pusher.err
+			}
+		} else if indexDefinition.JSONIndexer != nil {
+			newID, hasNext := indexDefinition.JSONIndexer(r.engine, id, jsonPusher)
+			hasMore = hasNext
+			nextID = newID
+
+			jsonPusher.Flush()
+
+			if jsonPusher.err != nil {
+				return jsonPusher.err
 			}
 		}
 
+		if event.To > 0 && nextID >= event.To {
+			hasMore = false
+		}
+
+		if hasMore {
+			r.redis.Set(idRedisKey, strconv.FormatUint(nextID, 10), 86400)
+		}
+
+		r.reindexProgress(event, nextID, pusher.pushed+jsonPusher.pushed-pushed)
+		pushed = pusher.pushed + jsonPusher.pushed
+
 		if !hasMore {
 			r.redis.Del(idRedisKey)
 
+			if event.Partitions > 0 && !r.reindexRangeFinished(event) {
+				return nil
+			}
+
+			r.reindexFinished(indexName, nil)
+
+			if hasFields {
+				r.redis.Del(fieldsKey)
+
+				break
+			}
+
+			if indexDefinition.BlueGreen {
+				return r.switchIndexVersionE(indexDefinition)
+			}
+
 			break
 		}
 
 		if nextID <= id {
-			panic(errors.Errorf("loop detected in indexer for index %s in pool %s", indexDefinition.Name, r.pool))
+			return fmt.Errorf("%w for index %s in pool %s", ErrIndexerLoop, indexDefinition.Name, r.pool)
 		}
 
 		id = nextID
 	}
+
+	return nil
 }
 
 type IndexerEventRedisearch struct {
-	Index string
+	Index      string
+	Partition  int
+	Partitions int
+	From       uint64
+	To         uint64
 }
@@ -0,0 +1,200 @@
+package redisearch
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// reindexRangeFinishedScript marks range as done only once, so redelivered event can't finish the reindex early
+const reindexRangeFinishedScript = `if redis.call('HSETNX', KEYS[1], ARGV[1], 1) == 0 then
+	return 0
+end
+local partitions = tonumber(ARGV[2])
+for i = 0, partitions - 1 do
+	if redis.call('HGET', KEYS[1], 'range:' .. i .. ':done') ~= '1' then
+		return 0
+	end
+end
+return 1`
+
+const (
+	redisSearchForceIndexStatusKeyPrefix = "_orm_force_index_status"
+
+	RedisSearchReindexStateIdle    = "idle"
+	RedisSearchReindexStateRunning = "running"
+	RedisSearchReindexStateFailed  = "failed"
+)
+
+type RedisSearchReindexStatus struct {
+	State           string
+	LastID          uint64
+	MaxID           uint64
+	DocumentsPushed uint64
+	StartedAt       time.Time
+	UpdatedAt       time.Time
+	Throughput      float64
+	ETA             time.Duration
+	Error           string
+	Ranges          []RedisSearchReindexRange
+}
+
+type RedisSearchReindexRange struct {
+	Partition int
+	From      uint64
+	To        uint64
+	LastID    uint64
+	Done      bool
+}
+
+func (r *RedisSearchEngine) ReindexStatus(index string) *RedisSearchReindexStatus {
+	status, err := r.ReindexStatusE(index)
+	checkError(err)
+
+	return status
+}
+
+func (r *RedisSearchEngine) ReindexStatusE(index string) (*RedisSearchReindexStatus, error) {
+	def, has := r.redisSearchIndices[index]
+	if !has {
+		return nil, fmt.Errorf("%w: %s in pool %s", ErrUnknownIndex, index, r.pool)
+	}
+
+	status := &RedisSearchReindexStatus{State: RedisSearchReindexStateIdle}
+
+	values := r.redis.HGetAll(redisSearchForceIndexStatusKeyPrefix + index)
+	if len(values) == 0 {
+		return status, nil
+	}
+
+	status.State = values["state"]
+	status.Error = values["error"]
+	status.LastID, _ = strconv.ParseUint(values["last_id"], 10, 64)
+	status.DocumentsPushed, _ = strconv.ParseUint(values["pushed"], 10, 64)
+	startID, _ := strconv.ParseUint(values["start_id"], 10, 64)
+
+	if started, err := strconv.ParseInt(values["started"], 10, 64); err == nil {
+		status.StartedAt = time.Unix(0, started)
+	}
+
+	if updated, err := strconv.ParseInt(values["updated"], 10, 64); err == nil {
+		status.UpdatedAt = time.Unix(0, updated)
+	}
+
+	partitions, _ := strconv.Atoi(values["partitions"])
+	processedIDs := uint64(0)
+
+	for i := 0; i < partitions; i++ {
+		prefix := "range:" + strconv.Itoa(i) + ":"
+		reindexRange := RedisSearchReindexRange{Partition: i, Done: values[prefix+"done"] == "1"}
+		reindexRange.From, _ = strconv.ParseUint(values[prefix+"from"], 10, 64)
+		reindexRange.To, _ = strconv.ParseUint(values[prefix+"to"], 10, 64)
+		reindexRange.LastID, _ = strconv.ParseUint(values[prefix+"last_id"], 10, 64)
+
+		if reindexRange.LastID > status.LastID {
+			status.LastID = reindexRange.LastID
+		}
+
+		if reindexRange.LastID > reindexRange.From {
+			processedIDs += reindexRange.LastID - reindexRange.From
+		}
+
+		status.Ranges = append(status.Ranges, reindexRange)
+	}
+
+	if partitions == 0 && status.LastID > startID {
+		processedIDs = status.LastID - startID
+	}
+
+	elapsed := status.UpdatedAt.Sub(status.StartedAt).Seconds()
+	if elapsed > 0 {
+		status.Throughput = float64(status.DocumentsPushed) / elapsed
+	}
+
+	if def.MaxID != nil {
+		status.MaxID = def.MaxID(r.engine)
+	}
+
+	remainingIDs := uint64(0)
+	if status.MaxID > startID+processedIDs {
+		remainingIDs = status.MaxID - startID - processedIDs
+	}
+
+	if status.State == RedisSearchReindexStateRunning && elapsed > 0 && processedIDs > 0 {
+		idsPerSecond := float64(processedIDs) / elapsed
+		status.ETA = time.Duration(float64(remainingIDs) / idsPerSecond * float64(time.Second))
+	}
+
+	return status, nil
+}
+
+func (r *RedisSearchEngine) reindexStarted(index string, lastID uint64) {
+	now := strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	r.redis.Del(redisSearchForceIndexStatusKeyPrefix + index)
+	r.redis.HSet(redisSearchForceIndexStatusKeyPrefix+index,
+		"state", RedisSearchReindexStateRunning,
+		"start_id", lastID,
+		"last_id", lastID,
+		"pushed", 0,
+		"started", now,
+		"updated", now,
+	)
+}
+
+func (r *RedisSearchEngine) reindexPartitionsStarted(index string, events []IndexerEventRedisearch) {
+	now := strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	values := []interface{}{
+		"state", RedisSearchReindexStateRunning,
+		"pushed", 0,
+		"started", now,
+		"updated", now,
+		"partitions", len(events),
+	}
+
+	for _, event := range events {
+		prefix := "range:" + strconv.Itoa(event.Partition) + ":"
+		values = append(values, prefix+"from", event.From, prefix+"to", event.To, prefix+"last_id", event.From)
+	}
+
+	r.redis.Del(redisSearchForceIndexStatusKeyPrefix + index)
+	r.redis.HSet(redisSearchForceIndexStatusKeyPrefix+index, values...)
+}
+
+func (r *RedisSearchEngine) reindexProgress(event IndexerEventRedisearch, lastID, pushed uint64) {
+	lastIDField := "last_id"
+	if event.Partitions > 0 {
+		lastIDField = "range:" + strconv.Itoa(event.Partition) + ":last_id"
+	}
+
+	r.redis.HIncrBy(redisSearchForceIndexStatusKeyPrefix+event.Index, "pushed", int64(pushed))
+	r.redis.HSet(redisSearchForceIndexStatusKeyPrefix+event.Index,
+		lastIDField, lastID,
+		"updated", strconv.FormatInt(time.Now().UnixNano(), 10),
+	)
+}
+
+// reindexRangeFinished marks range as done and reports if it was the last unfinished range
+func (r *RedisSearchEngine) reindexRangeFinished(event IndexerEventRedisearch) bool {
+	res := r.redis.Eval(
+		reindexRangeFinishedScript,
+		[]string{r.redis.AddNamespacePrefix(redisSearchForceIndexStatusKeyPrefix + event.Index)},
+		"range:"+strconv.Itoa(event.Partition)+":done",
+		event.Partitions,
+	)
+
+	last, _ := res.(int64)
+
+	return last == 1
+}
+
+func (r *RedisSearchEngine) reindexFinished(index string, err interface{}) {
+	if err != nil {
+		r.redis.HSet(redisSearchForceIndexStatusKeyPrefix+index, "state", RedisSearchReindexStateFailed, "error", fmt.Sprint(err))
+
+		return
+	}
+
+	r.redis.HSet(redisSearchForceIndexStatusKeyPrefix+index, "state", RedisSearchReindexStateIdle, "error", "")
+}
@@ -0,0 +1,138 @@
+package redisearch
+
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type RedisSearchSpellCheckTerm struct {
+	Term        string
+	Suggestions []RedisSearchSpellCheckSuggestion
+}
+
+type RedisSearchSpellCheckSuggestion struct {
+	Suggestion string
+	Score      float64
+}
+
+func (r *RedisSearchEngine) SpellCheck(index, query string, distance int, includeDicts, excludeDicts []string) []RedisSearchSpellCheckTerm {
+	terms, err := r.SpellCheckE(index, query, distance, includeDicts, excludeDicts)
+	checkError(err)
+
+	return terms
+}
+
+func (r *RedisSearchEngine) SpellCheckE(index, query string, distance int, includeDicts, excludeDicts []string) ([]RedisSearchSpellCheckTerm, error) {
+	args := []interface{}{"FT.SPELLCHECK", r.redis.AddNamespacePrefix(index), query}
+
+	if distance > 0 {
+		args = append(args, "DISTANCE", distance)
+	}
+
+	for _, dict := range includeDicts {
+		args = append(args, "TERMS", "INCLUDE", r.redis.AddNamespacePrefix(dict))
+	}
+
+	for _, dict := range excludeDicts {
+		args = append(args, "TERMS", "EXCLUDE", r.redis.AddNamespacePrefix(dict))
+	}
+
+	cmd := redis.NewSliceCmd(r.ctx, args...)
+
+	if err := r.process(cmd, "FT.SPELLCHECK", query); err != nil {
+		return nil, err
+	}
+
+	res, err := cmd.Result()
+	if err != nil {
+		return nil, err
+	}
+
+	terms := make([]RedisSearchSpellCheckTerm, 0, len(res))
+
+	for _, row := range res {
+		termRow, ok := row.([]interface{})
+		if !ok || len(termRow) != 3 {
+			return nil, fmt.Errorf("%w: spellcheck term is not an array of 3 elements", ErrUnexpectedReply)
+		}
+
+		term := RedisSearchSpellCheckTerm{Term: parseReplyString(termRow[1])}
+
+		suggestions, _ := termRow[2].([]interface{})
+
+		for _, suggestion := range suggestions {
+			suggestionRow, ok := suggestion.([]interface{})
+			if !ok || len(suggestionRow) != 2 {
+				return nil, fmt.Errorf("%w: spellcheck suggestion is not an array of 2 elements", ErrUnexpectedReply)
+			}
+
+			term.Suggestions = append(term.Suggestions, RedisSearchSpellCheckSuggestion{
+				Suggestion: parseReplyString(suggestionRow[1]),
+				Score:      parseReplyFloat(suggestionRow[0]),
+			})
+		}
+
+		terms = append(terms, term)
+	}
+
+	return terms, nil
+}
+
+func (r *RedisSearchEngine) DictAdd(dict string, terms ...string) int64 {
+	added, err := r.DictAddE(dict, terms...)
+	checkError(err)
+
+	return added
+}
+
+func (r *RedisSearchEngine) DictAddE(dict string, terms ...string) (int64, error) {
+	return r.dictUpdate("FT.DICTADD", dict, terms)
+}
+
+func (r *RedisSearchEngine) DictDel(dict string, terms ...string) int64 {
+	deleted, err := r.DictDelE(dict, terms...)
+	checkError(err)
+
+	return deleted
+}
+
+func (r *RedisSearchEngine) DictDelE(dict string, terms ...string) (int64, error) {
+	return r.dictUpdate("FT.DICTDEL", dict, terms)
+}
+
+func (r *RedisSearchEngine) DictDump(dict string) []string {
+	terms, err := r.DictDumpE(dict)
+	checkError(err)
+
+	return terms
+}
+
+func (r *RedisSearchEngine) DictDumpE(dict string) ([]string, error) {
+	cmd := redis.NewStringSliceCmd(r.ctx, "FT.DICTDUMP", r.redis.AddNamespacePrefix(dict))
+
+	if err := r.process(cmd, "FT.DICTDUMP", ""); err != nil {
+		return nil, err
+	}
+
+	return cmd.Result()
+}
+
+func (r *RedisSearchEngine) dictUpdate(command, dict string, terms []string) (int64, error) {
+	if len(terms) == 0 {
+		return 0, nil
+	}
+
+	args := []interface{}{command, r.redis.AddNamespacePrefix(dict)}
+	for _, term := range terms {
+		args = append(args, term)
+	}
+
+	cmd := redis.NewIntCmd(r.ctx, args...)
+
+	if err := r.process(cmd, command, ""); err != nil {
+		return 0, err
+	}
+
+	return cmd.Result()
+}
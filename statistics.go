@@ -6,19 +6,37 @@ type RedisSearchStatistics struct {
 }
 
 func (r *RedisSearchEngine) GetRedisSearchStatistics() []*RedisSearchStatistics {
+	result, err := r.GetRedisSearchStatisticsE()
+	checkError(err)
+
+	return result
+}
+
+func (r *RedisSearchEngine) GetRedisSearchStatisticsE() ([]*RedisSearchStatistics, error) {
 	result := make([]*RedisSearchStatistics, 0)
 
-	for _, indexName := range r.ListIndices() {
-		info := r.Info(indexName)
+	indices, err := r.ListIndicesE()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, indexName := range indices {
+		info, err := r.InfoE(indexName)
+		if err != nil {
+			return nil, err
+		}
+
 		index := r.GetRedisSearchIndex(indexName)
 
 		if index == nil {
-			continue
+			if index, _ = r.resolveVersionedIndex(indexName); index == nil {
+				continue
+			}
 		}
 
 		stat := &RedisSearchStatistics{Index: index, Info: info}
 		result = append(result, stat)
 	}
 
-	return result
+	return result, nil
 }
@@ -0,0 +1,273 @@
+package redisearch
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/latolukasz/beeorm/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// suggestUpdateScript keeps number of entities holding every suggestion in refs hash,
+// suggestion is removed from dictionary when last entity drops it
+const suggestUpdateScript = `local j = 0
+for i = 1, #KEYS, 2 do
+	local refs = redis.call('HINCRBY', KEYS[i + 1], ARGV[j + 2], ARGV[j + 1])
+	if refs <= 0 then
+		redis.call('HDEL', KEYS[i + 1], ARGV[j + 2])
+		redis.call('FT.SUGDEL', KEYS[i], ARGV[j + 2])
+	elseif refs == 1 and ARGV[j + 3] ~= '' then
+		redis.call('FT.SUGADD', KEYS[i], ARGV[j + 2], refs, 'PAYLOAD', ARGV[j + 3])
+	else
+		redis.call('FT.SUGADD', KEYS[i], ARGV[j + 2], refs)
+	end
+	j = j + 3
+end
+return #KEYS / 2`
+
+const suggestRefsSuffix = ":refs"
+
+type RedisSearchSuggestion struct {
+	Suggestion string
+	Score      float64
+	Payload    string
+}
+
+type redisSearchSuggestField struct {
+	dictionary string
+	payload    bool
+}
+
+type redisSearchSuggestions struct {
+	keys []string
+	args []interface{}
+}
+
+func (s *redisSearchSuggestions) add(key, value, payload string) {
+	s.keys = append(s.keys, key, key+suggestRefsSuffix)
+	s.args = append(s.args, 1, value, payload)
+}
+
+func (s *redisSearchSuggestions) remove(key, value string) {
+	s.keys = append(s.keys, key, key+suggestRefsSuffix)
+	s.args = append(s.args, -1, value, "")
+}
+
+func (s *redisSearchSuggestions) flush(redisCache beeorm.RedisCache) {
+	if len(s.keys) == 0 {
+		return
+	}
+
+	redisCache.Eval(suggestUpdateScript, s.keys, s.args...)
+
+	s.keys = s.keys[:0]
+	s.args = s.args[:0]
+}
+
+func (r *RedisSearchEngine) Suggest(dictionary, prefix string, fuzzy bool, max int) []RedisSearchSuggestion {
+	suggestions, err := r.SuggestE(dictionary, prefix, fuzzy, max)
+	checkError(err)
+
+	return suggestions
+}
+
+func (r *RedisSearchEngine) SuggestE(dictionary, prefix string, fuzzy bool, max int) ([]RedisSearchSuggestion, error) {
+	args := []interface{}{"FT.SUGGET", r.redis.AddNamespacePrefix(dictionary), prefix}
+
+	if fuzzy {
+		args = append(args, "FUZZY")
+	}
+
+	args = append(args, "WITHSCORES", "WITHPAYLOADS")
+
+	if max > 0 {
+		args = append(args, "MAX", max)
+	}
+
+	cmd := redis.NewSliceCmd(r.ctx, args...)
+
+	if err := r.process(cmd, "FT.SUGGET", prefix); err != nil {
+		return nil, err
+	}
+
+	res, err := cmd.Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res)%3 != 0 {
+		return nil, fmt.Errorf("%w: suggestions reply with %d elements", ErrUnexpectedReply, len(res))
+	}
+
+	suggestions := make([]RedisSearchSuggestion, len(res)/3)
+
+	for i := range suggestions {
+		suggestions[i] = RedisSearchSuggestion{
+			Suggestion: parseReplyString(res[i*3]),
+			Score:      parseReplyFloat(res[i*3+1]),
+			Payload:    parseReplyString(res[i*3+2]),
+		}
+	}
+
+	return suggestions, nil
+}
+
+func (tableSchema *tableSchemaRedisSearch) suggestColumns() []string {
+	columns := make([]string, 0, len(tableSchema.suggestFields))
+
+	for column := range tableSchema.suggestFields {
+		columns = append(columns, column)
+	}
+
+	sort.Strings(columns)
+
+	return columns
+}
+
+func (tableSchema *tableSchemaRedisSearch) suggestDictionaries() []string {
+	dictionaries := make([]string, 0)
+
+	for _, column := range tableSchema.suggestColumns() {
+		dictionary := tableSchema.suggestFields[column].dictionary
+
+		found := false
+
+		for _, existing := range dictionaries {
+			if existing == dictionary {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			dictionaries = append(dictionaries, dictionary)
+		}
+	}
+
+	return dictionaries
+}
+
+// suggestKeys returns dictionaries together with their refs hashes
+func (tableSchema *tableSchemaRedisSearch) suggestKeys() []string {
+	keys := make([]string, 0)
+
+	for _, dictionary := range tableSchema.suggestDictionaries() {
+		keys = append(keys, dictionary, dictionary+suggestRefsSuffix)
+	}
+
+	return keys
+}
+
+func (tableSchema *tableSchemaRedisSearch) addSuggestion(suggestions *redisSearchSuggestions, redisCache beeorm.RedisCache, column, value string, id uint64) {
+	if value == "" {
+		return
+	}
+
+	field := tableSchema.suggestFields[column]
+
+	payload := ""
+	if field.payload {
+		payload = strconv.FormatUint(id, 10)
+	}
+
+	suggestions.add(redisCache.AddNamespacePrefix(field.dictionary), value, payload)
+}
+
+// updateSuggestions runs right away, because flusher setter can't run FT.SUGADD. Changes are not rolled back
+// together with MySQL transaction, ForceReindex rebuilds dictionaries and their refs from MySQL.
+func (tableSchema *tableSchemaRedisSearch) updateSuggestions(engine beeorm.Engine, entitySchema beeorm.EntitySchema, event beeorm.EventEntityFlushed) {
+	if len(tableSchema.suggestFields) == 0 {
+		return
+	}
+
+	redisCache := engine.GetRedis(tableSchema.searchCacheName)
+	removed := map[string]string{}
+	added := map[string]string{}
+
+	deleted := event.Type() == beeorm.Delete
+
+	if tableSchema.hasFakeDelete && event.Type() == beeorm.Update {
+		if val, has := event.After()["FakeDelete"]; has && isFakeDeleted(val) {
+			deleted = true
+		}
+	}
+
+	for _, column := range tableSchema.suggestColumns() {
+		if event.Type() != beeorm.Insert {
+			if val, has := event.Before()[column]; has {
+				removed[column] = suggestionValue(val)
+			} else if deleted {
+				removed[column] = tableSchema.loadSuggestionValue(engine, entitySchema, column, event.EntityID())
+			}
+		}
+
+		if deleted {
+			continue
+		}
+
+		if val, has := event.After()[column]; has {
+			value := suggestionValue(val)
+
+			if removed[column] == value {
+				delete(removed, column)
+
+				continue
+			}
+
+			added[column] = value
+		}
+	}
+
+	suggestions := &redisSearchSuggestions{}
+
+	for _, column := range sortedKeys(removed) {
+		if removed[column] != "" {
+			suggestions.remove(redisCache.AddNamespacePrefix(tableSchema.suggestFields[column].dictionary), removed[column])
+		}
+	}
+
+	for _, column := range sortedKeys(added) {
+		tableSchema.addSuggestion(suggestions, redisCache, column, added[column], event.EntityID())
+	}
+
+	suggestions.flush(redisCache)
+}
+
+func (tableSchema *tableSchemaRedisSearch) loadSuggestionValue(engine beeorm.Engine, entitySchema beeorm.EntitySchema, column string, id uint64) string {
+	value := sql.NullString{}
+
+	engine.GetMysql(entitySchema.GetMysqlPool()).QueryRow(
+		beeorm.NewWhere("SELECT `"+column+"` FROM `"+entitySchema.GetTableName()+"` WHERE `ID` = ?", id),
+		&value,
+	)
+
+	return value.String
+}
+
+func suggestionValue(val interface{}) string {
+	value, ok := val.(string)
+	if !ok || value == "NULL" {
+		return ""
+	}
+
+	return strings.TrimSpace(value)
+}
+
+func isFakeDeleted(val interface{}) bool {
+	switch v := val.(type) {
+	case uint64:
+		return v > 0
+	case bool:
+		return v
+	case string:
+		id, _ := strconv.ParseUint(v, 10, 64)
+
+		return id > 0
+	}
+
+	return false
+}
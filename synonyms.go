@@ -0,0 +1,142 @@
+package redisearch
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisSearchSynonymsProvider can be implemented by searchable entities to register synonym groups in their index
+type RedisSearchSynonymsProvider interface {
+	RedisSearchSynonyms() map[string][]string
+}
+
+func (r *RedisSearchEngine) Synonyms(index string) map[string][]string {
+	synonyms, err := r.SynonymsE(index)
+	checkError(err)
+
+	return synonyms
+}
+
+func (r *RedisSearchEngine) SynonymsE(index string) (map[string][]string, error) {
+	cmd := redis.NewSliceCmd(r.ctx, "FT.SYNDUMP", r.redis.AddNamespacePrefix(index))
+
+	if err := r.process(cmd, "FT.SYNDUMP", ""); err != nil {
+		return nil, err
+	}
+
+	res, err := cmd.Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res)%2 != 0 {
+		return nil, fmt.Errorf("%w: synonyms reply with %d elements", ErrUnexpectedReply, len(res))
+	}
+
+	synonyms := map[string][]string{}
+
+	for i := 0; i < len(res); i += 2 {
+		term := parseReplyString(res[i])
+
+		groups, _ := res[i+1].([]interface{})
+		for _, group := range groups {
+			groupID := parseReplyString(group)
+			synonyms[groupID] = append(synonyms[groupID], term)
+		}
+	}
+
+	return normalizeSynonyms(synonyms), nil
+}
+
+func (r *RedisSearchEngine) updateSynonymsE(index *RedisSearchIndex, skipInitialScan bool) error {
+	for _, groupID := range sortedKeys(index.Synonyms) {
+		terms := index.Synonyms[groupID]
+		if len(terms) == 0 {
+			continue
+		}
+
+		args := []interface{}{"FT.SYNUPDATE", r.redis.AddNamespacePrefix(index.Name), groupID}
+
+		if skipInitialScan {
+			args = append(args, "SKIPINITIALSCAN")
+		}
+
+		for _, term := range terms {
+			args = append(args, term)
+		}
+
+		cmd := redis.NewStatusCmd(r.ctx, args...)
+
+		if err := r.process(cmd, "FT.SYNUPDATE", ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *RedisSearchEngine) addSynonymsAlter(index *RedisSearchIndex, documents uint64, changes []string) RedisSearchIndexAlter {
+	alter := RedisSearchIndexAlter{
+		Pool:      r.redis.GetCode(),
+		Name:      index.Name,
+		Changes:   changes,
+		Documents: documents,
+		Strategy:  RedisSearchIndexAlterStrategySynonyms,
+		search:    r,
+	}
+	alter.Query = fmt.Sprintf("FT.SYNUPDATE %s %v", index.Name, index.Synonyms)
+	alter.Execute = func() {
+		checkError(alter.search.updateSynonymsE(index, false))
+	}
+
+	return alter
+}
+
+func normalizeSynonyms(synonyms map[string][]string) map[string][]string {
+	normalized := map[string][]string{}
+
+	for groupID, terms := range synonyms {
+		unique := map[string]struct{}{}
+
+		for _, term := range terms {
+			unique[strings.ToLower(term)] = struct{}{}
+		}
+
+		if len(unique) == 0 {
+			continue
+		}
+
+		normalized[groupID] = sortedKeys(unique)
+	}
+
+	return normalized
+}
+
+// synonymsChanges reports if definition differs from synonyms in Redis and if it only adds new terms
+func synonymsChanges(definition, current map[string][]string) (changed, onlyAdded bool) {
+	definition = normalizeSynonyms(definition)
+
+	if reflect.DeepEqual(definition, current) {
+		return false, false
+	}
+
+	for groupID, currentTerms := range current {
+		definitionTerms, has := definition[groupID]
+		if !has {
+			return true, false
+		}
+
+		for _, term := range currentTerms {
+			i := sort.SearchStrings(definitionTerms, term)
+			if i == len(definitionTerms) || definitionTerms[i] != term {
+				return true, false
+			}
+		}
+	}
+
+	return true, true
+}
@@ -0,0 +1,55 @@
+package customindex
+
+import (
+	"strconv"
+
+	"github.com/latolukasz/beeorm/v2"
+
+	redisearch "github.com/coretrix/beeorm-redisearch-plugin"
+	"github.com/coretrix/beeorm-redisearch-plugin/test/entity"
+)
+
+const EntityOneBlueGreenCustomIndex = "custom_bluegreen_index_entity_one"
+
+func GetEntityOneBlueGreenIndex(redisSearchPool string) *redisearch.RedisSearchIndex {
+	index := &redisearch.RedisSearchIndex{}
+	index.Name = EntityOneBlueGreenCustomIndex
+	index.RedisPool = redisSearchPool
+	index.Prefixes = []string{EntityOneBlueGreenCustomIndex + ":"}
+	index.BlueGreen = true
+
+	// document fields
+	index.AddNumericField("ID", true, false)
+	index.AddTextField("String", 1, true, false, false)
+
+	// force reindex func
+	index.Indexer = entityOneBlueGreenIndexer
+
+	return index
+}
+
+func SetEntityOneBlueGreenIndexFields(pusher redisearch.RedisSearchIndexPusher, entities []*entity.TestEntityOne) {
+	for _, entityIter := range entities {
+		pusher.NewDocument(EntityOneBlueGreenCustomIndex + ":" + strconv.FormatUint(entityIter.ID, 10))
+		pusher.SetUint("ID", entityIter.ID)
+		pusher.SetString("String", entityIter.String)
+		pusher.PushDocument()
+	}
+}
+
+func entityOneBlueGreenIndexer(engine beeorm.Engine, lastID uint64, pusher redisearch.RedisSearchIndexPusher) (newID uint64, hasMore bool) {
+	where := beeorm.NewWhere("`ID` > ? AND `FakeDelete` = 0 ORDER BY `ID` ASC", lastID)
+
+	entities := make([]*entity.TestEntityOne, 0)
+	engine.Search(where, beeorm.NewPager(1, 1000), &entities)
+
+	if len(entities) == 0 {
+		return lastID, false
+	}
+
+	SetEntityOneBlueGreenIndexFields(pusher, entities)
+
+	lastID = entities[len(entities)-1].ID
+
+	return lastID, !(len(entities) < 1000)
+}
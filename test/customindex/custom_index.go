@@ -26,6 +26,7 @@ func GetEntityOneIndex(redisSearchPool string) *redisearch.RedisSearchIndex {
 	index.AddTagField("Bool", true, false, ",")
 	index.AddTagField("StringEnum", true, false, ",")
 	index.AddGeoField("Geo", true, false)
+	index.AddVectorField("Vector", redisearch.RedisSearchVectorAlgorithmFlat, 2, redisearch.RedisSearchVectorMetricL2, redisearch.RedisSearchVectorTypeFloat32)
 
 	// force reindex func
 	index.Indexer = entityOneIndexer
@@ -54,6 +55,7 @@ func SetEntityOneIndexFields(pusher redisearch.RedisSearchIndexPusher, entities
 		pusher.SetBool("Bool", entityIter.Bool)
 		pusher.SetTag("StringEnum", entityIter.StringEnum)
 		pusher.SetGeo("Geo", 1.2, 1.5)
+		pusher.SetVector("Vector", []float32{float32(entityIter.Int), float32(entityIter.Float)})
 		pusher.PushDocument()
 	}
 
@@ -0,0 +1,95 @@
+package customindex
+
+import (
+	"strconv"
+
+	"github.com/latolukasz/beeorm/v2"
+
+	redisearch "github.com/coretrix/beeorm-redisearch-plugin"
+	"github.com/coretrix/beeorm-redisearch-plugin/test/entity"
+)
+
+const EntityOneJSONCustomIndex = "custom_json_index_entity_one"
+
+type EntityOneJSONDocument struct {
+	ID      uint64
+	Int     int64
+	String  string
+	Details EntityOneJSONDetails
+}
+
+type EntityOneJSONDetails struct {
+	Bool bool
+	Tags []string
+}
+
+func GetEntityOneJSONIndex(redisSearchPool string) *redisearch.RedisSearchIndex {
+	index := &redisearch.RedisSearchIndex{}
+	index.Name = EntityOneJSONCustomIndex
+	index.RedisPool = redisSearchPool
+	index.KeyType = redisearch.RedisSearchIndexKeyTypeJSON
+	index.Prefixes = []string{EntityOneJSONCustomIndex + ":"}
+
+	// document fields
+	index.AddNumericField("ID", true, false)
+	index.AddNumericField("Int", true, false)
+	index.AddTextField("String", 1, true, false, false)
+	index.AddTagField("Bool", false, false, ",")
+	index.AddTagField("Tags", false, false, ",")
+
+	index.SetFieldPath("ID", "$.ID")
+	index.SetFieldPath("Int", "$.Int")
+	index.SetFieldPath("String", "$.String")
+	index.SetFieldPath("Bool", "$.Details.Bool")
+	index.SetFieldPath("Tags", "$.Details.Tags[*]")
+
+	// force reindex func
+	index.JSONIndexer = entityOneJSONIndexer
+
+	return index
+}
+
+func SetEntityOneJSONIndexDocuments(pusher redisearch.RedisSearchIndexJSONPusher, entities []*entity.TestEntityOne) {
+	deletedIDs := make([]string, 0)
+
+	for _, entityIter := range entities {
+		id := EntityOneJSONCustomIndex + ":" + strconv.FormatUint(entityIter.ID, 10)
+
+		if entityIter.FakeDelete {
+			deletedIDs = append(deletedIDs, id)
+
+			continue
+		}
+
+		pusher.SetDocument(id, EntityOneJSONDocument{
+			ID:     entityIter.ID,
+			Int:    entityIter.Int,
+			String: entityIter.String,
+			Details: EntityOneJSONDetails{
+				Bool: entityIter.Bool,
+				Tags: entityIter.StringSlice,
+			},
+		})
+	}
+
+	if len(deletedIDs) != 0 {
+		pusher.DeleteDocuments(deletedIDs...)
+	}
+}
+
+func entityOneJSONIndexer(engine beeorm.Engine, lastID uint64, pusher redisearch.RedisSearchIndexJSONPusher) (newID uint64, hasMore bool) {
+	where := beeorm.NewWhere("`ID` > ? AND `FakeDelete` >= 0 ORDER BY `ID` ASC", lastID)
+
+	entities := make([]*entity.TestEntityOne, 0)
+	engine.Search(where, beeorm.NewPager(1, 1000), &entities)
+
+	if len(entities) == 0 {
+		return lastID, false
+	}
+
+	SetEntityOneJSONIndexDocuments(pusher, entities)
+
+	lastID = entities[len(entities)-1].ID
+
+	return lastID, !(len(entities) < 1000)
+}
@@ -31,11 +31,14 @@ func createTestEngine(ctx context.Context) (beeorm.Engine, *redisearch.RedisSear
 
 		beeormRegistry.RegisterEntity(&entity.TestEntityOne{})
 		beeormRegistry.RegisterEntity(&entity.TestEntityTwo{})
+		beeormRegistry.RegisterEntity(&entity.TestEntityThree{})
 
 		beeormRegistry.RegisterEnumStruct("entity.TestEntityEnumAll", entity.TestEntityEnumAll)
 
 		rsPlugin := redisearch.Init("search_pool")
 		rsPlugin.RegisterCustomIndex(customindex.GetEntityOneIndex("search_pool"))
+		rsPlugin.RegisterCustomIndex(customindex.GetEntityOneJSONIndex("search_pool"))
+		rsPlugin.RegisterCustomIndex(customindex.GetEntityOneBlueGreenIndex("search_pool"))
 
 		beeormRegistry.RegisterPlugin(rsPlugin)
 		beeormRegistry.RegisterPlugin(fake_delete.Init(nil))
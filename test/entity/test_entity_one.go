@@ -29,7 +29,7 @@ type TestEntityOne struct {
 	IntPtr        *int64           `orm:"searchable;sortable"`
 	Float         float64          `orm:"searchable;sortable"`
 	FloatPtr      *float64         `orm:"searchable;sortable"`
-	String        string           `orm:"searchable;sortable"`
+	String        string           `orm:"searchable;sortable;suggest=entity_one_string;suggestPayload"`
 	StringPtr     *string          `orm:"searchable;sortable"`
 	StringEnum    string           `orm:"searchable;sortable;enum=entity.TestEntityEnumAll"`
 	StringEnumPtr *string          `orm:"searchable;sortable;enum=entity.TestEntityEnumAll"`
@@ -40,5 +40,6 @@ type TestEntityOne struct {
 	TimePtr       *time.Time       `orm:"searchable;sortable;time=true"`
 	ForeignKey    *TestEntityTwo   `orm:"searchable;sortable"`
 	Many          []*TestEntityTwo `orm:"searchable"`
+	Vector        []float32        `orm:"searchable;vector=flat,dim=2,metric=l2"`
 	FakeDelete    bool             `orm:"searchable"`
 }
@@ -0,0 +1,14 @@
+package entity
+
+import "github.com/latolukasz/beeorm/v2"
+
+type TestEntityThree struct {
+	beeorm.ORM  `orm:"table=test_entity_three;redisCache;redisSearch=search_pool;redisSearchOptions=offsets,highlight,freqs;stopwords=default;language=german;geo=Location:Lon,Lat"`
+	ID          uint64
+	Title       string `orm:"searchable;weight=3;phonetic=dm:en;suffixtrie"`
+	Description string `orm:"searchable"`
+	Name        string `orm:"searchable;sortable;unf"`
+	Language    string `orm:"language"`
+	Lon         float64
+	Lat         float64
+}
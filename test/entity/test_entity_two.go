@@ -7,3 +7,7 @@ type TestEntityTwo struct {
 	ID         uint64
 	Field      string `orm:"searchable;sortable"`
 }
+
+func (e *TestEntityTwo) RedisSearchSynonyms() map[string][]string {
+	return map[string][]string{"colors": {"red", "crimson"}}
+}
@@ -2,11 +2,14 @@ package test
 
 import (
 	"context"
+	"crypto/sha256"
+	"fmt"
 	"strconv"
 	"testing"
 	"time"
 
 	"github.com/latolukasz/beeorm/v2"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/xorcare/pointer"
 
@@ -3404,3 +3407,1117 @@ func TestGetRedisSearchAlters(t *testing.T) {
 	_, redisSearch := createTestEngine(context.Background())
 	assert.Equal(t, 0, len(redisSearch.GetRedisSearchAlters()))
 }
+
+func TestRedisSearchIdsEUnknownField(t *testing.T) {
+	_, redisSearch := createTestEngine(context.Background())
+
+	q := redisearch.NewRedisSearchQuery()
+	q.FilterInt("Unknown", 1)
+
+	_, _, err := redisSearch.RedisSearchIdsE(&entity.TestEntityOne{}, q, beeorm.NewPager(1, 100))
+	assert.ErrorIs(t, err, redisearch.ErrUnknownField)
+
+	q = redisearch.NewRedisSearchQuery()
+	q.FilterInt("String", 1)
+
+	_, _, err = redisSearch.RedisSearchIdsE(&entity.TestEntityOne{}, q, beeorm.NewPager(1, 100))
+	assert.ErrorIs(t, err, redisearch.ErrFilterNotAllowed)
+
+	assert.Panics(t, func() {
+		redisSearch.RedisSearchIds(&entity.TestEntityOne{}, q, beeorm.NewPager(1, 100))
+	})
+}
+
+func TestRedisSearchEInvalidQuery(t *testing.T) {
+	_, redisSearch := createTestEngine(context.Background())
+
+	q := redisearch.NewRedisSearchQuery()
+	q.QueryFieldPrefixMatch("String", "a")
+
+	results := make([]*entity.TestEntityOne, 0)
+	_, err := redisSearch.RedisSearchE(q, beeorm.NewPager(1, 100), &results)
+	assert.ErrorIs(t, err, redisearch.ErrInvalidQuery)
+}
+
+func TestSearchKeysEPagerLimit(t *testing.T) {
+	_, redisSearch := createTestEngine(context.Background())
+
+	q := redisearch.NewRedisSearchQuery()
+
+	_, _, err := redisSearch.SearchKeysE("entity.TestEntityOne", q, beeorm.NewPager(1, 10001))
+	assert.ErrorIs(t, err, redisearch.ErrLimitExceeded)
+
+	_, _, err = redisSearch.SearchKeysE("entity.TestEntityOne", q, nil)
+	assert.ErrorIs(t, err, redisearch.ErrMissingPager)
+}
+
+func TestSearchRawEUnknownIndex(t *testing.T) {
+	_, redisSearch := createTestEngine(context.Background())
+
+	q := redisearch.NewRedisSearchQuery()
+
+	_, _, err := redisSearch.SearchRawE("unknown_index", q, beeorm.NewPager(1, 100))
+	assert.ErrorIs(t, err, redisearch.ErrUnknownIndex)
+
+	info, err := redisSearch.InfoE("unknown_index")
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+}
+
+func TestSearchRawESyntaxError(t *testing.T) {
+	_, redisSearch := createTestEngine(context.Background())
+
+	q := redisearch.NewRedisSearchQuery()
+	q.QueryRaw("@String:(")
+
+	_, _, err := redisSearch.SearchRawE("entity.TestEntityOne", q, beeorm.NewPager(1, 100))
+	assert.ErrorIs(t, err, redisearch.ErrSyntax)
+
+	var redisSearchError *redisearch.RedisSearchError
+	assert.ErrorAs(t, err, &redisSearchError)
+	assert.NotEmpty(t, redisSearchError.Message)
+}
+
+func TestAggregateEWithCancelledContext(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	flusher := engine.NewFlusher()
+	for i := 1; i <= 100; i++ {
+		flusher.Track(&entity.TestEntityOne{Int: int64(i % 10), String: "test string " + strconv.Itoa(i)})
+	}
+	flusher.Flush()
+
+	q := redisearch.NewRedisSearchQuery()
+	a := q.Aggregate()
+	a.GroupByField("@Int", redisearch.NewAggregateReduceCount("count"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := redisSearch.WithContext(ctx).RedisSearchAggregateE(&entity.TestEntityOne{}, a, beeorm.NewPager(1, 100))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, total := redisSearch.RedisSearchAggregate(&entity.TestEntityOne{}, a, beeorm.NewPager(1, 100))
+	assert.Equal(t, uint64(10), total)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	time.Sleep(time.Millisecond)
+
+	_, _, err = redisSearch.WithContext(ctx).RedisSearchAggregateE(&entity.TestEntityOne{}, a, beeorm.NewPager(1, 100))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWhereExpression(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	engine.Flush(&entity.TestEntityOne{Int: 1, String: "test string 1", Bool: true})
+	engine.Flush(&entity.TestEntityOne{Int: 2, String: "test string 2", Bool: false})
+	engine.Flush(&entity.TestEntityOne{Int: 3, String: "test string 3", Bool: true})
+	engine.Flush(&entity.TestEntityOne{Int: 4, String: "test string 4", Bool: false})
+
+	q := redisearch.NewRedisSearchQuery()
+	q.Where(redisearch.NewRedisSearchAnd(
+		redisearch.NewRedisSearchOr(
+			redisearch.NewRedisSearchQuery().FilterInt("Int", 1),
+			redisearch.NewRedisSearchQuery().FilterBool("Bool", false),
+		),
+		redisearch.NewRedisSearchNot(
+			redisearch.NewRedisSearchQuery().FilterInt("Int", 4).FilterString("String", "test string 4"),
+		),
+	))
+	q.Sort("Int", false)
+
+	ids, total := redisSearch.RedisSearchIds(&entity.TestEntityOne{}, q, beeorm.NewPager(1, 100))
+	assert.Equal(t, uint64(2), total)
+	assert.Equal(t, []uint64{1, 2}, ids)
+
+	q = redisearch.NewRedisSearchQuery()
+	q.FilterIntGreaterEqual("Int", 2)
+	q.Where(redisearch.NewRedisSearchNot(redisearch.NewRedisSearchQuery().FilterBool("Bool", true)))
+	q.Sort("Int", false)
+
+	ids, total = redisSearch.RedisSearchIds(&entity.TestEntityOne{}, q, beeorm.NewPager(1, 100))
+	assert.Equal(t, uint64(2), total)
+	assert.Equal(t, []uint64{2, 4}, ids)
+}
+
+func TestWhereExpressionValidation(t *testing.T) {
+	_, redisSearch := createTestEngine(context.Background())
+
+	q := redisearch.NewRedisSearchQuery()
+	q.Where(redisearch.NewRedisSearchOr(
+		redisearch.NewRedisSearchQuery().FilterInt("Int", 1),
+		redisearch.NewRedisSearchNot(redisearch.NewRedisSearchQuery().FilterInt("String", 1)),
+	))
+
+	_, _, err := redisSearch.RedisSearchIdsE(&entity.TestEntityOne{}, q, beeorm.NewPager(1, 100))
+	assert.ErrorIs(t, err, redisearch.ErrFilterNotAllowed)
+
+	q = redisearch.NewRedisSearchQuery()
+	q.Where(redisearch.NewRedisSearchAnd(redisearch.NewRedisSearchQuery().FilterInt("Unknown", 1)))
+
+	_, _, err = redisSearch.RedisSearchIdsE(&entity.TestEntityOne{}, q, beeorm.NewPager(1, 100))
+	assert.ErrorIs(t, err, redisearch.ErrUnknownField)
+
+	q = redisearch.NewRedisSearchQuery()
+	q.Where(redisearch.NewRedisSearchOr())
+
+	_, _, err = redisSearch.RedisSearchIdsE(&entity.TestEntityOne{}, q, beeorm.NewPager(1, 100))
+	assert.ErrorIs(t, err, redisearch.ErrInvalidQuery)
+}
+
+func TestQueryCompile(t *testing.T) {
+	q := redisearch.NewRedisSearchQuery()
+	q.FilterTag("Tag", "b")
+	q.FilterInt("Int", 1, 2)
+	q.FilterString("String", "test")
+	q.FilterBool("Bool", true)
+	q.FilterNotInt("IntPtr", 3)
+	q.FilterFloatGreaterEqual("Float", 1.5)
+	q.FilterGeo("Location", 12.5, 41.9, 10, "km")
+	q.Sort("Int", true)
+	q.Return("ID")
+
+	args, err := q.Compile("entity.TestEntityOne")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		"FT.SEARCH",
+		"entity.TestEntityOne",
+		`@Float:[1.49999 +inf] @Int:[1 1]|@Int:[2 2] @Bool:{ true } @Tag:{ b } @String:( "test" ) (@IntPtr:[-inf (3] | @IntPtr:[(3 +inf])`,
+		"GEOFILTER", "Location", 12.5, 41.9, float64(10), "km",
+		"SORTBY", "Int", "DESC",
+		"RETURN", 1, "ID",
+	}, args)
+
+	for i := 0; i < 10; i++ {
+		again, err := q.Compile("entity.TestEntityOne")
+		assert.NoError(t, err)
+		assert.Equal(t, args, again)
+	}
+
+	assert.Equal(t, args[2], q.String())
+	assert.Equal(t, "*", redisearch.NewRedisSearchQuery().String())
+
+	q = redisearch.NewRedisSearchQuery()
+	q.Where(redisearch.NewRedisSearchOr(
+		redisearch.NewRedisSearchQuery().FilterInt("Int", 1),
+		redisearch.NewRedisSearchNot(redisearch.NewRedisSearchQuery().FilterTag("Tag", "a-b")),
+	))
+	assert.Equal(t, `((@Int:[1 1]) | (-(@Tag:{ a\-b })))`, q.String())
+
+	q = redisearch.NewRedisSearchQuery()
+	q.QueryFieldPrefixMatch("String", "a")
+
+	_, err = q.Compile("entity.TestEntityOne")
+	assert.ErrorIs(t, err, redisearch.ErrInvalidQuery)
+
+	q = redisearch.NewRedisSearchQuery()
+	q.Where(redisearch.NewRedisSearchOr(
+		redisearch.NewRedisSearchQuery().FilterInt("Int", 1),
+		redisearch.NewRedisSearchQuery().WithinPolygon("Zone", "POLYGON((0 0, 1 0, 1 1, 0 0))"),
+	))
+
+	_, err = q.Compile("entity.TestEntityOne")
+	assert.ErrorIs(t, err, redisearch.ErrInvalidQuery)
+}
+
+func TestExplain(t *testing.T) {
+	_, redisSearch := createTestEngine(context.Background())
+
+	q := redisearch.NewRedisSearchQuery()
+	q.FilterInt("Int", 1)
+	q.FilterBool("Bool", true)
+
+	plan := redisSearch.Explain("entity.TestEntityOne", q)
+	assert.Contains(t, plan, "INTERSECT")
+	assert.Contains(t, plan, "NUMERIC")
+
+	planCli := redisSearch.ExplainCli("entity.TestEntityOne", q)
+	assert.NotEmpty(t, planCli)
+
+	q = redisearch.NewRedisSearchQuery()
+	q.FilterBool("Bool", true)
+	q.KNN("Vector", 2, []float32{1, 1})
+
+	plan, err := redisSearch.ExplainE("entity.TestEntityOne", q)
+	assert.NoError(t, err)
+	assert.Contains(t, plan, "VECTOR")
+
+	q = redisearch.NewRedisSearchQuery()
+	q.QueryRaw("@String:(")
+
+	_, err = redisSearch.ExplainE("entity.TestEntityOne", q)
+	assert.ErrorIs(t, err, redisearch.ErrSyntax)
+}
+
+func TestProfile(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	engine.Flush(&entity.TestEntityOne{Int: 1, Bool: true})
+	engine.Flush(&entity.TestEntityOne{Int: 1, Bool: false})
+	engine.Flush(&entity.TestEntityOne{Int: 2, Bool: true})
+
+	q := redisearch.NewRedisSearchQuery()
+	q.FilterInt("Int", 1)
+	q.FilterBool("Bool", true)
+	q.Sort("Int", false)
+
+	profile := redisSearch.Profile("entity.TestEntityOne", q, beeorm.NewPager(1, 100))
+	assert.Equal(t, uint64(1), profile.Total)
+	assert.NotNil(t, profile.Iterators)
+	assert.Equal(t, "INTERSECT", profile.Iterators.Type)
+	assert.NotEmpty(t, profile.Iterators.Children)
+	assert.NotEmpty(t, profile.ResultProcessors)
+	assert.Equal(t, "Index", profile.ResultProcessors[0].Type)
+
+	a := redisearch.NewRedisSearchQuery().FilterInt("Int", 1, 2).Aggregate()
+	a.GroupByField("@Int", redisearch.NewAggregateReduceCount("count"))
+
+	profile = redisSearch.ProfileAggregate("entity.TestEntityOne", a, beeorm.NewPager(1, 100))
+	assert.NotNil(t, profile.Iterators)
+	assert.NotEmpty(t, profile.ResultProcessors)
+}
+
+func TestAggregateCursor(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	flusher := engine.NewFlusher()
+	for i := 1; i <= 100; i++ {
+		flusher.Track(&entity.TestEntityOne{Int: int64(i % 10)})
+	}
+	flusher.Flush()
+
+	a := redisearch.NewRedisSearchQuery().Aggregate()
+	a.GroupByField("@Int", redisearch.NewAggregateReduceCount("count"))
+	a.WithCursor(3, time.Minute)
+
+	cursor := redisSearch.RedisSearchAggregateCursor(&entity.TestEntityOne{}, a)
+
+	ints := map[string]string{}
+	batches := 0
+
+	for {
+		rows, has := cursor.Next()
+		if !has {
+			break
+		}
+
+		batches++
+
+		for _, row := range rows {
+			ints[row["Int"]] = row["count"]
+		}
+	}
+
+	cursor.Close()
+
+	assert.Len(t, ints, 10)
+	assert.Equal(t, "10", ints["1"])
+	assert.GreaterOrEqual(t, batches, 4)
+
+	cursor = redisSearch.RedisSearchAggregateCursor(&entity.TestEntityOne{}, a)
+
+	rows, has := cursor.Next()
+	assert.True(t, has)
+	assert.Len(t, rows, 3)
+
+	assert.NoError(t, cursor.CloseE())
+
+	_, has = cursor.Next()
+	assert.False(t, has)
+}
+
+func TestIterate(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	flusher := engine.NewFlusher()
+	for i := 1; i <= 50; i++ {
+		flusher.Track(&entity.TestEntityOne{Int: int64(i % 7), Bool: i%2 == 0})
+	}
+	flusher.Flush()
+
+	// updated documents are reindexed after the others, so index order differs from ID order
+	for _, id := range []uint64{2, 4, 16, 30} {
+		updated := &entity.TestEntityOne{}
+		engine.LoadByID(id, updated)
+		updated.Float = 1
+		engine.Flush(updated)
+	}
+
+	q := redisearch.NewRedisSearchQuery()
+	q.FilterBool("Bool", true)
+	q.Sort("Int", false)
+
+	ids := make([]uint64, 0)
+	lastInt := int64(-1)
+	lastID := uint64(0)
+
+	redisSearch.Iterate(&entity.TestEntityOne{}, q, 4, func(entities interface{}) bool {
+		rows := entities.([]*entity.TestEntityOne)
+		assert.LessOrEqual(t, len(rows), 4)
+
+		for _, row := range rows {
+			assert.True(t, row.Bool)
+			assert.True(t, row.Int > lastInt || (row.Int == lastInt && row.ID > lastID))
+
+			lastInt = row.Int
+			lastID = row.ID
+			ids = append(ids, row.ID)
+		}
+
+		return true
+	})
+
+	assert.Len(t, ids, 25)
+
+	q = redisearch.NewRedisSearchQuery()
+
+	count := 0
+
+	redisSearch.Iterate(&entity.TestEntityOne{}, q, 10, func(entities interface{}) bool {
+		count += len(entities.([]*entity.TestEntityOne))
+
+		return count < 20
+	})
+
+	assert.Equal(t, 20, count)
+
+	q = redisearch.NewRedisSearchQuery()
+	q.Sort("ID", true)
+
+	ids = ids[:0]
+
+	redisSearch.Iterate(&entity.TestEntityOne{}, q, 20, func(entities interface{}) bool {
+		for _, row := range entities.([]*entity.TestEntityOne) {
+			ids = append(ids, row.ID)
+		}
+
+		return true
+	})
+
+	assert.Len(t, ids, 50)
+	assert.Equal(t, uint64(50), ids[0])
+	assert.Equal(t, uint64(1), ids[49])
+}
+
+func TestCustomIndexKNN(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	engine.Flush(&entity.TestEntityOne{Int: 1, Float: 1, Bool: true})
+	engine.Flush(&entity.TestEntityOne{Int: 5, Float: 5, Bool: true})
+	engine.Flush(&entity.TestEntityOne{Int: 2, Float: 2, Bool: false})
+	engine.Flush(&entity.TestEntityOne{Int: 10, Float: 10, Bool: true})
+
+	reindexCustomIndexEntityOne(engine)
+
+	redisSearch.HandleRedisIndexerEvent(customindex.EntityOneCustomIndex)
+
+	q := redisearch.NewRedisSearchQuery()
+	q.FilterBool("Bool", true)
+	q.KNN("Vector", 2, []float32{4, 4})
+
+	total, rows := redisSearch.SearchResult(customindex.EntityOneCustomIndex, q, beeorm.NewPager(1, 10))
+	assert.Equal(t, uint64(2), total)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, customindex.EntityOneCustomIndex+":2", rows[0].Key)
+	assert.Equal(t, float64(2), rows[0].Distance)
+	assert.Equal(t, customindex.EntityOneCustomIndex+":1", rows[1].Key)
+	assert.Equal(t, float64(18), rows[1].Distance)
+
+	args, err := q.Compile(customindex.EntityOneCustomIndex)
+	assert.NoError(t, err)
+	assert.Equal(t, "(@Bool:{ true })=>[KNN 2 @Vector $knn_vector AS __Vector_score]", args[2])
+	assert.Contains(t, args, "PARAMS")
+	assert.Equal(t, []interface{}{"DIALECT", 2}, args[7:9])
+
+	q.KNN("Vector", 1, []float32{1, 1})
+
+	args, err = q.Compile(customindex.EntityOneCustomIndex)
+	assert.NoError(t, err)
+	assert.Equal(t, "(@Bool:{ true })=>[KNN 1 @Vector $knn_vector AS __Vector_score]", args[2])
+	assert.Equal(t, []interface{}{"PARAMS", 2, "knn_vector"}, args[3:6])
+	assert.Equal(t, []interface{}{"DIALECT", 2}, args[7:9])
+
+	total, rows = redisSearch.SearchResult(customindex.EntityOneCustomIndex, q, beeorm.NewPager(1, 10))
+	assert.Equal(t, uint64(1), total)
+	assert.Equal(t, customindex.EntityOneCustomIndex+":1", rows[0].Key)
+}
+
+func TestRedisSearchIdsKNN(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	engine.Flush(&entity.TestEntityOne{Int: 1, Bool: true, Vector: []float32{1, 1}})
+	engine.Flush(&entity.TestEntityOne{Int: 2, Bool: true, Vector: []float32{5, 5}})
+	engine.Flush(&entity.TestEntityOne{Int: 3, Bool: false, Vector: []float32{4, 4}})
+	engine.Flush(&entity.TestEntityOne{Int: 4, Bool: true, Vector: []float32{10, 10}})
+	engine.Flush(&entity.TestEntityOne{Int: 5, Bool: true})
+
+	q := redisearch.NewRedisSearchQuery()
+	q.FilterBool("Bool", true)
+	q.KNN("Vector", 2, []float32{4, 4})
+
+	ids, total := redisSearch.RedisSearchIds(&entity.TestEntityOne{}, q, beeorm.NewPager(1, 10))
+	assert.Equal(t, uint64(2), total)
+	assert.Equal(t, []uint64{2, 1}, ids)
+
+	e := &entity.TestEntityOne{}
+	engine.LoadByID(2, e)
+	e.Vector = nil
+	engine.Flush(e)
+
+	ids, total = redisSearch.RedisSearchIds(&entity.TestEntityOne{}, q, beeorm.NewPager(1, 10))
+	assert.Equal(t, uint64(2), total)
+	assert.Equal(t, []uint64{1, 4}, ids)
+
+	engine.LoadByID(1, e)
+	e.Vector = []float32{1, 1, 1}
+	assert.NotPanics(t, func() {
+		engine.Flush(e)
+	})
+
+	ids, total = redisSearch.RedisSearchIds(&entity.TestEntityOne{}, q, beeorm.NewPager(1, 10))
+	assert.Equal(t, uint64(1), total)
+	assert.Equal(t, []uint64{4}, ids)
+
+	q = redisearch.NewRedisSearchQuery()
+	q.KNN("Int", 2, []float32{4, 4})
+
+	_, _, err := redisSearch.RedisSearchIdsE(&entity.TestEntityOne{}, q, beeorm.NewPager(1, 10))
+	assert.ErrorIs(t, err, redisearch.ErrFilterNotAllowed)
+}
+
+func TestReindexCustomJSONIndex(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	engine.Flush(&entity.TestEntityOne{
+		Int:         1,
+		String:      "test string 1",
+		Bool:        true,
+		StringSlice: []string{"a", "b"},
+	})
+
+	engine.Flush(&entity.TestEntityOne{
+		Int:         2,
+		String:      "test string 2",
+		Bool:        false,
+		StringSlice: []string{"b", "c"},
+	})
+
+	info := redisSearch.Info(customindex.EntityOneJSONCustomIndex)
+	assert.Equal(t, redisearch.RedisSearchIndexKeyTypeJSON, info.Definition.KeyType)
+	assert.Equal(t, "$.Details.Bool", info.Fields[3].Path)
+	assert.Equal(t, "Bool", info.Fields[3].Name)
+
+	redisSearch.HandleRedisIndexerEvent(customindex.EntityOneJSONCustomIndex)
+
+	q := redisearch.NewRedisSearchQuery()
+	q.FilterTag("Tags", "b")
+	q.Sort("ID", false)
+
+	ids, total := redisearch.GetEntityIDs(redisSearch, customindex.EntityOneJSONCustomIndex, q, beeorm.NewPager(1, 1000))
+	assert.Equal(t, uint64(2), total)
+	assert.Equal(t, []uint64{1, 2}, ids)
+
+	q = redisearch.NewRedisSearchQuery()
+	q.FilterBool("Bool", true)
+	q.FilterInt("Int", 1)
+
+	ids, total = redisearch.GetEntityIDs(redisSearch, customindex.EntityOneJSONCustomIndex, q, beeorm.NewPager(1, 1000))
+	assert.Equal(t, uint64(1), total)
+	assert.Equal(t, []uint64{1}, ids)
+
+	pusher := redisearch.NewRedisSearchIndexJSONPusher(engine, "search_pool")
+	pusher.DeleteDocuments(customindex.EntityOneJSONCustomIndex + ":1")
+	pusher.Flush()
+
+	q = redisearch.NewRedisSearchQuery()
+	q.FilterTag("Tags", "b")
+
+	_, total = redisearch.GetEntityIDs(redisSearch, customindex.EntityOneJSONCustomIndex, q, beeorm.NewPager(1, 1000))
+	assert.Equal(t, uint64(1), total)
+
+	pusher.DeleteDocuments(customindex.EntityOneJSONCustomIndex + ":2")
+	pusher.SetDocument(customindex.EntityOneJSONCustomIndex+":2", customindex.EntityOneJSONDocument{
+		ID:      2,
+		Details: customindex.EntityOneJSONDetails{Tags: []string{"b"}},
+	})
+	pusher.SetDocument(customindex.EntityOneJSONCustomIndex+":3", customindex.EntityOneJSONDocument{
+		ID:      3,
+		Details: customindex.EntityOneJSONDetails{Tags: []string{"b"}},
+	})
+	pusher.DeleteDocuments(customindex.EntityOneJSONCustomIndex + ":3")
+	pusher.Flush()
+
+	ids, total = redisearch.GetEntityIDs(redisSearch, customindex.EntityOneJSONCustomIndex, q, beeorm.NewPager(1, 1000))
+	assert.Equal(t, uint64(1), total)
+	assert.Equal(t, []uint64{2}, ids)
+
+	assert.Empty(t, redisSearch.GetRedisSearchAlters())
+}
+
+func TestSuggest(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	entityOne := &entity.TestEntityOne{String: "apple pie"}
+	entityTwo := &entity.TestEntityOne{String: "apricot"}
+	engine.Flush(entityOne, entityTwo)
+
+	suggestions := redisSearch.Suggest("entity_one_string", "ap", false, 10)
+	assert.Len(t, suggestions, 2)
+
+	payloads := []string{suggestions[0].Payload, suggestions[1].Payload}
+	assert.ElementsMatch(t, []string{"1", "2"}, payloads)
+	assert.Greater(t, suggestions[0].Score, float64(0))
+
+	entityOne.String = "banana"
+	engine.Flush(entityOne)
+
+	suggestions = redisSearch.Suggest("entity_one_string", "ap", false, 10)
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, "apricot", suggestions[0].Suggestion)
+
+	suggestions = redisSearch.Suggest("entity_one_string", "bam", true, 10)
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, "banana", suggestions[0].Suggestion)
+	assert.Equal(t, "1", suggestions[0].Payload)
+
+	engine.Delete(entityTwo)
+
+	assert.Empty(t, redisSearch.Suggest("entity_one_string", "ap", false, 10))
+
+	engine.GetRedis("search_pool").Del("entity_one_string")
+	engine.GetRedis("search_pool").HSet("entity_one_string:refs", "banana", 5, "apricot", 1)
+	assert.Empty(t, redisSearch.Suggest("entity_one_string", "ban", false, 10))
+
+	redisSearch.HandleRedisIndexerEvent("entity.TestEntityOne")
+
+	suggestions = redisSearch.Suggest("entity_one_string", "ban", false, 10)
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, "1", suggestions[0].Payload)
+	assert.Equal(t, map[string]string{"banana": "1"}, engine.GetRedis("search_pool").HGetAll("entity_one_string:refs"))
+
+	entityThree := &entity.TestEntityOne{String: "cherry"}
+	entityFour := &entity.TestEntityOne{String: "cherry"}
+	engine.Flush(entityThree, entityFour)
+
+	suggestions = redisSearch.Suggest("entity_one_string", "che", false, 10)
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, strconv.FormatUint(entityThree.GetID(), 10), suggestions[0].Payload)
+
+	entityThree.String = "cranberry"
+	engine.Flush(entityThree)
+
+	suggestions = redisSearch.Suggest("entity_one_string", "che", false, 10)
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, "cherry", suggestions[0].Suggestion)
+
+	engine.Delete(entityFour)
+
+	assert.Empty(t, redisSearch.Suggest("entity_one_string", "che", false, 10))
+}
+
+func TestSpellCheck(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	engine.Flush(&entity.TestEntityOne{String: "hello world"})
+
+	redisSearch.HandleRedisIndexerEvent("entity.TestEntityOne")
+
+	terms := redisSearch.SpellCheck("entity.TestEntityOne", "helo", 1, nil, nil)
+	assert.Len(t, terms, 1)
+	assert.Equal(t, "helo", terms[0].Term)
+	assert.Len(t, terms[0].Suggestions, 1)
+	assert.Equal(t, "hello", terms[0].Suggestions[0].Suggestion)
+	assert.Greater(t, terms[0].Suggestions[0].Score, float64(0))
+
+	assert.Equal(t, int64(2), redisSearch.DictAdd("brands", "acme", "globex"))
+	assert.ElementsMatch(t, []string{"acme", "globex"}, redisSearch.DictDump("brands"))
+
+	terms = redisSearch.SpellCheck("entity.TestEntityOne", "acmee", 1, []string{"brands"}, nil)
+	assert.Len(t, terms, 1)
+	assert.Equal(t, "acme", terms[0].Suggestions[0].Suggestion)
+
+	assert.Equal(t, int64(1), redisSearch.DictDel("brands", "acme"))
+	assert.Equal(t, []string{"globex"}, redisSearch.DictDump("brands"))
+
+	_, err := redisSearch.SpellCheckE("unknown_index", "helo", 1, nil, nil)
+	assert.ErrorIs(t, err, redisearch.ErrUnknownIndex)
+
+	redisSearch.DictDel("brands", "globex")
+}
+
+func TestSynonyms(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	assert.Equal(t, map[string][]string{"colors": {"crimson", "red"}}, redisSearch.Synonyms("entity.TestEntityTwo"))
+
+	engine.Flush(&entity.TestEntityTwo{Field: "crimson car"})
+
+	redisSearch.HandleRedisIndexerEvent("entity.TestEntityTwo")
+
+	q := redisearch.NewRedisSearchQuery()
+	q.Query("red")
+
+	total, _ := redisSearch.SearchKeys("entity.TestEntityTwo", q, beeorm.NewPager(1, 10))
+	assert.Equal(t, uint64(1), total)
+	assert.Empty(t, redisSearch.GetRedisSearchAlters())
+
+	cmd := redis.NewStatusCmd(context.Background(), "FT.SYNUPDATE", "entity.TestEntityTwo", "colors", "scarlet")
+	assert.NoError(t, engine.GetRedis("search_pool").Process(context.Background(), cmd))
+
+	alters := redisSearch.GetRedisSearchAlters()
+	assert.Len(t, alters, 1)
+	assert.Equal(t, []string{"different synonyms"}, alters[0].Changes)
+
+	alters[0].Execute()
+
+	assert.Equal(t, map[string][]string{"colors": {"crimson", "red"}}, redisSearch.Synonyms("entity.TestEntityTwo"))
+}
+
+func TestBlueGreenReindex(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	active, building := redisSearch.IndexVersions(customindex.EntityOneBlueGreenCustomIndex)
+	assert.Equal(t, uint64(1), active)
+	assert.Equal(t, uint64(0), building)
+
+	entityOne := &entity.TestEntityOne{String: "blue green"}
+	engine.Flush(entityOne)
+
+	pusher := redisSearch.IndexPusher(customindex.EntityOneBlueGreenCustomIndex)
+	customindex.SetEntityOneBlueGreenIndexFields(pusher, []*entity.TestEntityOne{entityOne})
+	pusher.Flush()
+
+	q := redisearch.NewRedisSearchQuery()
+	q.FilterString("String", "blue green")
+
+	ids, total := redisearch.GetEntityIDs(redisSearch, customindex.EntityOneBlueGreenCustomIndex, q, beeorm.NewPager(1, 10))
+	assert.Equal(t, uint64(1), total)
+	assert.Equal(t, []uint64{1}, ids)
+
+	engine.Flush(&entity.TestEntityOne{String: "blue green"})
+
+	redisSearch.ForceReindex(customindex.EntityOneBlueGreenCustomIndex)
+
+	active, building = redisSearch.IndexVersions(customindex.EntityOneBlueGreenCustomIndex)
+	assert.Equal(t, uint64(1), active)
+	assert.Equal(t, uint64(2), building)
+
+	_, total = redisearch.GetEntityIDs(redisSearch, customindex.EntityOneBlueGreenCustomIndex, q, beeorm.NewPager(1, 10))
+	assert.Equal(t, uint64(1), total)
+	assert.Empty(t, redisSearch.GetRedisSearchAlters())
+
+	redisSearch.HandleRedisIndexerEvent(customindex.EntityOneBlueGreenCustomIndex)
+
+	active, building = redisSearch.IndexVersions(customindex.EntityOneBlueGreenCustomIndex)
+	assert.Equal(t, uint64(2), active)
+	assert.Equal(t, uint64(0), building)
+
+	q.Sort("ID", false)
+
+	ids, total = redisearch.GetEntityIDs(redisSearch, customindex.EntityOneBlueGreenCustomIndex, q, beeorm.NewPager(1, 10))
+	assert.Equal(t, uint64(2), total)
+	assert.Equal(t, []uint64{1, 2}, ids)
+
+	indices := redisSearch.ListIndices()
+	assert.Contains(t, indices, customindex.EntityOneBlueGreenCustomIndex+"_v2")
+	assert.NotContains(t, indices, customindex.EntityOneBlueGreenCustomIndex+"_v1")
+	assert.Empty(t, redisSearch.GetRedisSearchAlters())
+
+	entityThree := &entity.TestEntityOne{String: "blue green"}
+	engine.Flush(entityThree)
+
+	customindex.SetEntityOneBlueGreenIndexFields(pusher, []*entity.TestEntityOne{entityThree})
+	pusher.Flush()
+
+	ids, total = redisearch.GetEntityIDs(redisSearch, customindex.EntityOneBlueGreenCustomIndex, q, beeorm.NewPager(1, 10))
+	assert.Equal(t, uint64(3), total)
+	assert.Equal(t, []uint64{1, 2, 3}, ids)
+}
+
+func TestAlterAddFields(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	engine.Flush(&entity.TestEntityOne{Int: 1, String: "test string 1"})
+	reindexCustomIndexEntityOne(engine)
+
+	def := redisSearch.GetRedisSearchIndex(customindex.EntityOneCustomIndex)
+	fields := def.Fields
+
+	defer func() {
+		def.Fields = fields
+	}()
+
+	def.AddNumericField("Extra", false, false)
+
+	alters := redisSearch.GetRedisSearchAlters()
+	assert.Len(t, alters, 1)
+	assert.Equal(t, redisearch.RedisSearchIndexAlterStrategyAddFields, alters[0].Strategy)
+	assert.Equal(t, []string{"new field Extra"}, alters[0].Changes)
+
+	alters[0].Execute()
+
+	info := redisSearch.Info(customindex.EntityOneCustomIndex)
+	assert.Equal(t, "Extra", info.Fields[len(info.Fields)-1].Name)
+	assert.Empty(t, redisSearch.GetRedisSearchAlters())
+
+	redisSearch.HandleRedisIndexerEvent(customindex.EntityOneCustomIndex)
+
+	q := redisearch.NewRedisSearchQuery()
+	q.FilterString("String", "test string 1")
+	assert.Equal(t, uint64(1), redisSearch.SearchCount(customindex.EntityOneCustomIndex, q))
+
+	def.Fields[0].Sortable = false
+
+	alters = redisSearch.GetRedisSearchAlters()
+	assert.Len(t, alters, 1)
+	assert.Equal(t, redisearch.RedisSearchIndexAlterStrategyRebuild, alters[0].Strategy)
+
+	def.Fields[0].Sortable = true
+
+	blueGreen := redisSearch.GetRedisSearchIndex(customindex.EntityOneBlueGreenCustomIndex)
+	blueGreenFields := blueGreen.Fields
+
+	defer func() {
+		blueGreen.Fields = blueGreenFields
+	}()
+
+	redisSearch.ForceReindex(customindex.EntityOneBlueGreenCustomIndex)
+	blueGreen.AddNumericField("Extra", false, false)
+
+	alters = redisSearch.GetRedisSearchAlters()
+	assert.Len(t, alters, 1)
+	assert.Equal(t, redisearch.RedisSearchIndexAlterStrategyRebuild, alters[0].Strategy)
+}
+
+func TestReindexStatus(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	status := redisSearch.ReindexStatus("entity.TestEntityOne")
+	assert.Equal(t, redisearch.RedisSearchReindexStateIdle, status.State)
+	assert.True(t, status.StartedAt.IsZero())
+
+	engine.Flush(&entity.TestEntityOne{Int: 1}, &entity.TestEntityOne{Int: 2}, &entity.TestEntityOne{Int: 3})
+
+	redisSearch.HandleRedisIndexerEvent("entity.TestEntityOne")
+
+	status = redisSearch.ReindexStatus("entity.TestEntityOne")
+	assert.Equal(t, redisearch.RedisSearchReindexStateIdle, status.State)
+	assert.Equal(t, uint64(3), status.LastID)
+	assert.Equal(t, uint64(3), status.MaxID)
+	assert.Equal(t, uint64(3), status.DocumentsPushed)
+	assert.False(t, status.StartedAt.IsZero())
+	assert.False(t, status.UpdatedAt.Before(status.StartedAt))
+	assert.Empty(t, status.Error)
+
+	_, err := redisSearch.ReindexStatusE("unknown_index")
+	assert.ErrorIs(t, err, redisearch.ErrUnknownIndex)
+}
+
+func TestForceReindexPartitioned(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	for i := 1; i <= 5; i++ {
+		engine.Flush(&entity.TestEntityOne{Int: int64(i)})
+	}
+
+	redisSearch.ForceReindexPartitioned("entity.TestEntityOne", 2)
+
+	status := redisSearch.ReindexStatus("entity.TestEntityOne")
+	assert.Equal(t, redisearch.RedisSearchReindexStateRunning, status.State)
+	assert.Len(t, status.Ranges, 2)
+	assert.Equal(t, uint64(0), status.Ranges[0].From)
+	assert.Equal(t, uint64(3), status.Ranges[0].To)
+	assert.Equal(t, uint64(3), status.Ranges[1].From)
+	assert.Equal(t, uint64(0), status.Ranges[1].To)
+
+	redisSearch.HandleIndexerEvent(redisearch.IndexerEventRedisearch{Index: "entity.TestEntityOne", Partition: 1, Partitions: 2, From: 3})
+
+	status = redisSearch.ReindexStatus("entity.TestEntityOne")
+	assert.Equal(t, redisearch.RedisSearchReindexStateRunning, status.State)
+	assert.False(t, status.Ranges[0].Done)
+	assert.True(t, status.Ranges[1].Done)
+	assert.Equal(t, uint64(5), status.Ranges[1].LastID)
+
+	// redelivered event doesn't finish the reindex
+	redisSearch.HandleIndexerEvent(redisearch.IndexerEventRedisearch{Index: "entity.TestEntityOne", Partition: 1, Partitions: 2, From: 3})
+
+	status = redisSearch.ReindexStatus("entity.TestEntityOne")
+	assert.Equal(t, redisearch.RedisSearchReindexStateRunning, status.State)
+	assert.False(t, status.Ranges[0].Done)
+
+	redisSearch.HandleIndexerEvent(redisearch.IndexerEventRedisearch{Index: "entity.TestEntityOne", Partition: 0, Partitions: 2, To: 3})
+
+	status = redisSearch.ReindexStatus("entity.TestEntityOne")
+	assert.Equal(t, redisearch.RedisSearchReindexStateIdle, status.State)
+	assert.True(t, status.Ranges[0].Done)
+	assert.Equal(t, uint64(3), status.Ranges[0].LastID)
+	assert.Equal(t, uint64(7), status.DocumentsPushed)
+
+	q := redisearch.NewRedisSearchQuery()
+	assert.Equal(t, uint64(5), redisSearch.SearchCount("entity.TestEntityOne", q))
+}
+
+func TestReindexThrottle(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	for i := 1; i <= 10; i++ {
+		engine.Flush(&entity.TestEntityOne{Int: int64(i)})
+	}
+
+	throttled := redisSearch.WithReindexThrottle(&redisearch.RedisSearchReindexThrottle{
+		MaxDocumentsPerSecond: 20,
+		MaxUsedMemory:         1 << 40,
+		CheckIndexing:         true,
+	})
+
+	start := time.Now()
+	throttled.HandleRedisIndexerEvent("entity.TestEntityOne")
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+
+	q := redisearch.NewRedisSearchQuery()
+	assert.Equal(t, uint64(10), redisSearch.SearchCount("entity.TestEntityOne", q))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Panics(t, func() {
+		throttled.WithContext(ctx).HandleRedisIndexerEvent("entity.TestEntityOne")
+	})
+	assert.Equal(t, redisearch.RedisSearchReindexStateFailed, redisSearch.ReindexStatus("entity.TestEntityOne").State)
+
+	err := throttled.WithContext(ctx).HandleRedisIndexerEventE("entity.TestEntityOne")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	throttled = redisSearch.WithReindexThrottle(&redisearch.RedisSearchReindexThrottle{
+		MaxDocumentsPerSecond: 100,
+		MaxUsedMemory:         1,
+		MemoryCheckInterval:   10 * time.Millisecond,
+		MaxPause:              50 * time.Millisecond,
+	})
+
+	err = throttled.HandleRedisIndexerEventE("entity.TestEntityOne")
+	assert.ErrorIs(t, err, redisearch.ErrThrottleTimeout)
+	assert.Equal(t, redisearch.RedisSearchReindexStateFailed, redisSearch.ReindexStatus("entity.TestEntityOne").State)
+}
+
+func TestVerifyIndex(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	entities := []*entity.TestEntityTwo{{Field: "one"}, {Field: "two"}, {Field: "three"}}
+	engine.Flush(entities[0], entities[1], entities[2])
+
+	report := redisSearch.VerifyIndex(&entity.TestEntityTwo{}, nil)
+	assert.True(t, report.IsValid())
+	assert.Equal(t, uint64(3), report.Checked)
+
+	prefix := fmt.Sprintf("%x", sha256.Sum256([]byte("entity.TestEntityTwo")))[0:5] + ":"
+	searchRedis := engine.GetRedis("search_pool")
+	searchRedis.Del(prefix + strconv.FormatUint(entities[0].ID, 10))
+	searchRedis.HSet(prefix+strconv.FormatUint(entities[1].ID, 10), "Field", "changed")
+	searchRedis.HSet(prefix+"100", "Field", "orphan")
+
+	report = redisSearch.VerifyIndex(&entity.TestEntityTwo{}, &redisearch.RedisSearchVerifyOptions{BatchSize: 2})
+	assert.False(t, report.IsValid())
+	assert.Equal(t, uint64(3), report.Checked)
+	assert.Equal(t, []uint64{entities[0].ID}, report.Missing)
+	assert.Equal(t, []uint64{entities[1].ID}, report.Stale)
+	assert.Equal(t, []uint64{100}, report.Orphaned)
+	assert.False(t, report.Repaired)
+
+	report = redisSearch.VerifyIndex(&entity.TestEntityTwo{}, &redisearch.RedisSearchVerifyOptions{Repair: true})
+	assert.True(t, report.Repaired)
+
+	report = redisSearch.VerifyIndex(&entity.TestEntityTwo{}, nil)
+	assert.True(t, report.IsValid())
+	assert.Equal(t, "two", searchRedis.HGetAll(prefix + strconv.FormatUint(entities[1].ID, 10))["Field"])
+	assert.Empty(t, searchRedis.HGetAll(prefix+"100"))
+}
+
+func TestTextFieldOptions(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	mouse := &entity.TestEntityThree{Title: "Mouse", Description: "works with keyboard", Name: "Mouse"}
+	keyboard := &entity.TestEntityThree{Title: "Keyboard", Description: "mechanical", Name: "Keyboard"}
+	john := &entity.TestEntityThree{Title: "John", Description: "author", Name: "John"}
+	engine.Flush(mouse, keyboard, john)
+
+	info := redisSearch.Info("entity.TestEntityThree")
+
+	for _, field := range info.Fields {
+		switch field.Name {
+		case "Title":
+			assert.Equal(t, float64(3), field.Weight)
+			assert.NotEmpty(t, field.Phonetic)
+			assert.True(t, field.WithSuffixTrie)
+		case "Name":
+			assert.True(t, field.Sortable)
+			assert.True(t, field.UNF)
+		}
+	}
+
+	assert.Empty(t, redisSearch.GetRedisSearchAlters())
+
+	q := redisearch.NewRedisSearchQuery().Query("keyboard")
+	ids, total := redisSearch.RedisSearchIds(&entity.TestEntityThree{}, q, beeorm.NewPager(1, 10))
+	assert.Equal(t, uint64(2), total)
+	assert.Equal(t, []uint64{keyboard.ID, mouse.ID}, ids)
+
+	q = redisearch.NewRedisSearchQuery().Query("@Title:Jon")
+	ids, _ = redisSearch.RedisSearchIds(&entity.TestEntityThree{}, q, beeorm.NewPager(1, 10))
+	assert.Equal(t, []uint64{john.ID}, ids)
+
+	def := redisSearch.GetRedisSearchIndex("entity.TestEntityThree")
+	def.SetFieldPhonetic("Description", redisearch.RedisSearchPhoneticFrench)
+
+	defer func() {
+		def.SetFieldPhonetic("Description", "")
+	}()
+
+	alters := redisSearch.GetRedisSearchAlters()
+	assert.Len(t, alters, 1)
+	assert.Equal(t, []string{"different field phonetic Description"}, alters[0].Changes)
+}
+
+func TestEntityIndexOptions(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	def := redisSearch.GetRedisSearchIndex("entity.TestEntityThree")
+	assert.False(t, def.NoOffsets)
+	assert.False(t, def.NoNHL)
+	assert.False(t, def.NoFreqs)
+	assert.Nil(t, def.StopWords)
+	assert.Equal(t, "german", def.DefaultLanguage)
+
+	info := redisSearch.Info("entity.TestEntityThree")
+	assert.False(t, info.Options.NoOffsets)
+	assert.False(t, info.Options.NoFreqs)
+	assert.Nil(t, info.StopWords)
+
+	engine.Flush(&entity.TestEntityThree{Title: "Mouse", Description: "works with wireless keyboard", Name: "Mouse"})
+
+	q := redisearch.NewRedisSearchQuery().Query("@Description:keyboard").Highlight("Description")
+	total, rows := redisSearch.SearchResult("entity.TestEntityThree", q, beeorm.NewPager(1, 10))
+	assert.Equal(t, uint64(1), total)
+
+	for i := 0; i < len(rows[0].Fields)-1; i += 2 {
+		if rows[0].Fields[i] == "Description" {
+			assert.Equal(t, "works with wireless <b>keyboard</b>", rows[0].Fields[i+1])
+		}
+	}
+
+	q = redisearch.NewRedisSearchQuery().Query("@Description:(works keyboard)").Slop(1).InOrder()
+	assert.Equal(t, uint64(0), redisSearch.SearchCount("entity.TestEntityThree", q))
+
+	q = redisearch.NewRedisSearchQuery().Query("@Description:(works keyboard)").Slop(2).InOrder()
+	assert.Equal(t, uint64(1), redisSearch.SearchCount("entity.TestEntityThree", q))
+
+	def.StopWords = []string{}
+
+	defer func() {
+		def.StopWords = nil
+	}()
+
+	alters := redisSearch.GetRedisSearchAlters()
+	assert.Len(t, alters, 1)
+	assert.Equal(t, []string{"different stop words"}, alters[0].Changes)
+}
+
+func TestEntityLanguageField(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	info := redisSearch.Info("entity.TestEntityThree")
+	assert.Equal(t, "Language", info.Definition.LanguageField)
+	assert.Equal(t, "german", info.Definition.DefaultLanguage)
+
+	english := &entity.TestEntityThree{Title: "Houses", Description: "running", Language: "English"}
+	unknown := &entity.TestEntityThree{Title: "Häuser", Description: "laufen", Language: "klingon"}
+	engine.Flush(english, unknown)
+
+	prefix := fmt.Sprintf("%x", sha256.Sum256([]byte("entity.TestEntityThree")))[0:5] + ":"
+	searchRedis := engine.GetRedis("search_pool")
+	assert.Equal(t, "english", searchRedis.HGetAll(prefix + strconv.FormatUint(english.ID, 10))["Language"])
+	assert.NotContains(t, searchRedis.HGetAll(prefix+strconv.FormatUint(unknown.ID, 10)), "Language")
+
+	english.Language = ""
+	engine.Flush(english)
+	assert.NotContains(t, searchRedis.HGetAll(prefix+strconv.FormatUint(english.ID, 10)), "Language")
+
+	english.Language = "french"
+	engine.Flush(english)
+	searchRedis.FlushDB()
+
+	redisSearch = redisearch.NewRedisSearch(context.Background(), engine, "search_pool")
+	for _, alter := range redisSearch.GetRedisSearchAlters() {
+		alter.Execute()
+	}
+
+	redisSearch.HandleRedisIndexerEvent("entity.TestEntityThree")
+	assert.Equal(t, "french", searchRedis.HGetAll(prefix + strconv.FormatUint(english.ID, 10))["Language"])
+	assert.Equal(t, uint64(2), redisSearch.SearchCount("entity.TestEntityThree", redisearch.NewRedisSearchQuery()))
+}
+
+func TestEntityGeoField(t *testing.T) {
+	engine, redisSearch := createTestEngine(context.Background())
+
+	berlin := &entity.TestEntityThree{Title: "Berlin", Lon: 13.404954, Lat: 52.520008}
+	paris := &entity.TestEntityThree{Title: "Paris", Lon: 2.352222, Lat: 48.856613}
+	engine.Flush(berlin, paris)
+
+	q := redisearch.NewRedisSearchQuery().FilterGeo("Location", 13.4, 52.5, 50, "km")
+	ids, total := redisSearch.RedisSearchIds(&entity.TestEntityThree{}, q, beeorm.NewPager(1, 10))
+	assert.Equal(t, uint64(1), total)
+	assert.Equal(t, []uint64{berlin.ID}, ids)
+
+	paris.Lat = 52.5
+	paris.Lon = 13.3
+	engine.Flush(paris)
+
+	prefix := fmt.Sprintf("%x", sha256.Sum256([]byte("entity.TestEntityThree")))[0:5] + ":"
+	searchRedis := engine.GetRedis("search_pool")
+	assert.Equal(t, "13.300000,52.500000", searchRedis.HGetAll(prefix + strconv.FormatUint(paris.ID, 10))["Location"])
+
+	paris.Lat = 48.856613
+	engine.Flush(paris)
+	assert.Equal(t, "13.300000,48.856613", searchRedis.HGetAll(prefix + strconv.FormatUint(paris.ID, 10))["Location"])
+
+	searchRedis.Del(prefix+strconv.FormatUint(berlin.ID, 10), prefix+strconv.FormatUint(paris.ID, 10))
+	redisSearch.HandleRedisIndexerEvent("entity.TestEntityThree")
+
+	ids, _ = redisSearch.RedisSearchIds(&entity.TestEntityThree{}, q, beeorm.NewPager(1, 10))
+	assert.Equal(t, []uint64{berlin.ID}, ids)
+	assert.True(t, redisSearch.VerifyIndex(&entity.TestEntityThree{}, nil).IsValid())
+
+	_, _, err := redisSearch.RedisSearchIdsE(&entity.TestEntityThree{}, redisearch.NewRedisSearchQuery().FilterGeo("Title", 13.4, 52.5, 50, "km"), beeorm.NewPager(1, 10))
+	assert.ErrorIs(t, err, redisearch.ErrFilterNotAllowed)
+}
+
+func TestGeoShapeQueryCompile(t *testing.T) {
+	zone := "POLYGON((13.3 52.4, 13.5 52.4, 13.5 52.6, 13.3 52.6, 13.3 52.4))"
+
+	q := redisearch.NewRedisSearchQuery()
+	q.WithinPolygon("Zone", zone)
+	q.ContainsPoint("Zone", 13.4, 52.5)
+	q.Intersects("Area", zone)
+	q.Disjoint("Area", zone)
+
+	args, err := q.Compile("zones")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		"FT.SEARCH",
+		"zones",
+		"@Zone:[WITHIN $shape_0] @Zone:[CONTAINS $shape_1] @Area:[INTERSECTS $shape_2] @Area:[DISJOINT $shape_3]",
+		"PARAMS", 8, "shape_0", zone, "shape_1", "POINT(13.4 52.5)", "shape_2", zone, "shape_3", zone,
+		"DIALECT", 3,
+	}, args)
+
+	args, err = redisearch.NewRedisSearchQuery().Dialect(4).Intersects("Area", zone).Compile("zones")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"FT.SEARCH", "zones", "@Area:[INTERSECTS $shape_0]", "PARAMS", 2, "shape_0", zone, "DIALECT", 4}, args)
+
+	_, redisSearch := createTestEngine(context.Background())
+
+	_, _, err = redisSearch.RedisSearchIdsE(&entity.TestEntityThree{}, redisearch.NewRedisSearchQuery().WithinPolygon("Title", zone), beeorm.NewPager(1, 10))
+	assert.ErrorIs(t, err, redisearch.ErrFilterNotAllowed)
+}
@@ -0,0 +1,210 @@
+package redisearch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultThrottleChunk               = 1000
+	defaultThrottleMemoryCheckInterval = time.Second
+	defaultThrottleIndexingBackoff     = 100 * time.Millisecond
+	defaultThrottleMaxIndexingBackoff  = 10 * time.Second
+	defaultThrottleMaxPause            = 10 * time.Minute
+)
+
+// RedisSearchReindexThrottle limits how fast indexer pushes documents to Redis
+type RedisSearchReindexThrottle struct {
+	MaxDocumentsPerSecond int
+	MaxUsedMemory         uint64
+	MemoryCheckInterval   time.Duration
+	IndexingBackoff       time.Duration
+	MaxIndexingBackoff    time.Duration
+	MaxPause              time.Duration
+	CheckIndexing         bool
+}
+
+type reindexThrottle struct {
+	search   *RedisSearchEngine
+	index    string
+	options  *RedisSearchReindexThrottle
+	chunk    uint64
+	started  time.Time
+	lastWait uint64
+	err      error
+}
+
+// WithReindexThrottle returns a copy of the engine which throttles indexers of indices without own Throttle
+func (r *RedisSearchEngine) WithReindexThrottle(throttle *RedisSearchReindexThrottle) *RedisSearchEngine {
+	clone := *r
+	clone.reindexThrottle = throttle
+
+	return &clone
+}
+
+func (r *RedisSearchEngine) newReindexThrottle(index *RedisSearchIndex) *reindexThrottle {
+	options := index.Throttle
+	if options == nil {
+		options = r.reindexThrottle
+	}
+
+	if options == nil {
+		return nil
+	}
+
+	chunk := uint64(defaultThrottleChunk)
+
+	if options.MaxDocumentsPerSecond > 0 {
+		chunk = uint64(options.MaxDocumentsPerSecond / 10)
+		if chunk == 0 {
+			chunk = 1
+		}
+	}
+
+	return &reindexThrottle{search: r, index: index.Name, options: options, chunk: chunk, started: time.Now()}
+}
+
+func (t *reindexThrottle) shouldWait(pushed uint64) bool {
+	return pushed-t.lastWait >= t.chunk
+}
+
+// wait blocks until next chunk can be pushed, once it fails it keeps returning the same error
+func (t *reindexThrottle) wait(pushed uint64) error {
+	if t.err == nil {
+		t.err = t.throttle(pushed)
+	}
+
+	return t.err
+}
+
+func (t *reindexThrottle) throttle(pushed uint64) error {
+	t.lastWait = pushed
+
+	if t.options.MaxDocumentsPerSecond > 0 {
+		expected := time.Duration(float64(pushed) / float64(t.options.MaxDocumentsPerSecond) * float64(time.Second))
+
+		if elapsed := time.Since(t.started); expected > elapsed {
+			if err := t.sleep(expected - elapsed); err != nil {
+				return err
+			}
+		}
+	}
+
+	maxPause := t.options.MaxPause
+	if maxPause == 0 {
+		maxPause = defaultThrottleMaxPause
+	}
+
+	if t.options.MaxUsedMemory > 0 {
+		interval := t.options.MemoryCheckInterval
+		if interval == 0 {
+			interval = defaultThrottleMemoryCheckInterval
+		}
+
+		deadline := time.Now().Add(maxPause)
+
+		for {
+			used, err := t.usedMemory()
+			if err != nil {
+				return err
+			}
+
+			if used <= t.options.MaxUsedMemory {
+				break
+			}
+
+			if time.Now().After(deadline) {
+				return fmt.Errorf("%w: used memory %d above %d for %s", ErrThrottleTimeout, used, t.options.MaxUsedMemory, maxPause)
+			}
+
+			if err = t.sleep(interval); err != nil {
+				return err
+			}
+		}
+	}
+
+	if t.options.CheckIndexing {
+		backoff := t.options.IndexingBackoff
+		if backoff == 0 {
+			backoff = defaultThrottleIndexingBackoff
+		}
+
+		maxBackoff := t.options.MaxIndexingBackoff
+		if maxBackoff == 0 {
+			maxBackoff = defaultThrottleMaxIndexingBackoff
+		}
+
+		deadline := time.Now().Add(maxPause)
+		index := t.indexingName()
+
+		for {
+			info, err := t.search.InfoE(index)
+			if err != nil {
+				return err
+			}
+
+			if info == nil || !info.Indexing {
+				break
+			}
+
+			if time.Now().After(deadline) {
+				return fmt.Errorf("%w: index %s still indexing after %s", ErrThrottleTimeout, index, maxPause)
+			}
+
+			if err = t.sleep(backoff); err != nil {
+				return err
+			}
+
+			if backoff *= 2; backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		}
+	}
+
+	return nil
+}
+
+// indexingName returns name of the version being built for blue/green index, alias points to the served version
+func (t *reindexThrottle) indexingName() string {
+	if def, has := t.search.redisSearchIndices[t.index]; has && def.BlueGreen {
+		if _, building := t.search.IndexVersions(t.index); building != 0 {
+			return versionedIndexName(t.index, building)
+		}
+	}
+
+	return t.index
+}
+
+func (t *reindexThrottle) sleep(duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-t.search.ctx.Done():
+		return t.search.ctx.Err()
+	}
+}
+
+func (t *reindexThrottle) usedMemory() (uint64, error) {
+	cmd := redis.NewStringCmd(t.search.ctx, "INFO", "memory")
+
+	if err := t.search.process(cmd, "INFO", "INFO memory"); err != nil {
+		return 0, err
+	}
+
+	for _, line := range strings.Split(cmd.Val(), "\r\n") {
+		if strings.HasPrefix(line, "used_memory:") {
+			used, _ := strconv.ParseUint(line[len("used_memory:"):], 10, 64)
+
+			return used, nil
+		}
+	}
+
+	return 0, nil
+}
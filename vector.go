@@ -0,0 +1,26 @@
+package redisearch
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+func vectorToBlob(vector []float32) string {
+	blob := make([]byte, len(vector)*4)
+
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(blob[i*4:], math.Float32bits(v))
+	}
+
+	return string(blob)
+}
+
+func vectorFloat64ToBlob(vector []float64) string {
+	blob := make([]byte, len(vector)*8)
+
+	for i, v := range vector {
+		binary.LittleEndian.PutUint64(blob[i*8:], math.Float64bits(v))
+	}
+
+	return string(blob)
+}
@@ -0,0 +1,374 @@
+package redisearch
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/latolukasz/beeorm/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+const verifyHGetAllScript = `
+local result = {}
+for i, key in ipairs(KEYS) do
+	result[i] = redis.call('HGETALL', key)
+end
+return result
+`
+
+type RedisSearchVerifyOptions struct {
+	BatchSize int
+	Repair    bool
+}
+
+type RedisSearchVerifyReport struct {
+	Checked  uint64
+	Missing  []uint64
+	Stale    []uint64
+	Orphaned []uint64
+	Repaired bool
+}
+
+func (r *RedisSearchVerifyReport) IsValid() bool {
+	return len(r.Missing) == 0 && len(r.Stale) == 0 && len(r.Orphaned) == 0
+}
+
+type verifyDocument struct {
+	id     uint64
+	fields map[string]interface{}
+}
+
+func (r *RedisSearchEngine) VerifyIndex(entity beeorm.Entity, opts *RedisSearchVerifyOptions) *RedisSearchVerifyReport {
+	report, err := r.VerifyIndexE(entity, opts)
+	checkError(err)
+
+	return report
+}
+
+func (r *RedisSearchEngine) VerifyIndexE(entity beeorm.Entity, opts *RedisSearchVerifyOptions) (*RedisSearchVerifyReport, error) {
+	if opts == nil {
+		opts = &RedisSearchVerifyOptions{}
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = entityIndexerPage
+	}
+
+	schema := r.engine.GetRegistry().GetEntitySchemaForEntity(entity)
+
+	redisSearchSchema, err := getRedisSearchSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := redisSearchSchema.redisSearchPrefix
+
+	if redisSearchSchema.index.BlueGreen {
+		if active, _ := r.IndexVersions(redisSearchSchema.index.Name); active > 0 {
+			prefix = versionedPrefix(prefix, active)
+		}
+	}
+
+	report := &RedisSearchVerifyReport{}
+	columns := sortedKeys(redisSearchSchema.mapBindToRedisSearch)
+	geoFields := sortedKeys(redisSearchSchema.geoFields)
+	query := redisSearchSchema.indexerQuery(schema.GetTableName(), append(append([]string{}, columns...), redisSearchSchema.geoColumns(geoFields)...), 0, batchSize)
+	mysql := r.engine.GetMysql(schema.GetMysqlPool())
+
+	var pusher RedisSearchIndexPusher
+	if opts.Repair {
+		pusher = r.IndexPusher(redisSearchSchema.index.Name)
+	}
+
+	lastID := uint64(0)
+
+	for {
+		documents := redisSearchSchema.loadDocuments(mysql, query, columns, geoFields, lastID)
+		if len(documents) == 0 {
+			break
+		}
+
+		lastID = documents[len(documents)-1].id
+
+		if err = r.verifyDocuments(report, prefix, append(append([]string{}, columns...), geoFields...), documents); err != nil {
+			return nil, err
+		}
+
+		if pusher != nil {
+			redisSearchSchema.verifyRepair(pusher, report, documents)
+		}
+
+		if len(documents) < batchSize {
+			break
+		}
+	}
+
+	if err = r.verifyOrphans(report, redisSearchSchema, schema, prefix, batchSize); err != nil {
+		return nil, err
+	}
+
+	if pusher != nil && len(report.Orphaned) > 0 {
+		for _, id := range report.Orphaned {
+			pusher.DeleteDocuments(redisSearchSchema.redisSearchPrefix + strconv.FormatUint(id, 10))
+		}
+
+		pusher.Flush()
+	}
+
+	report.Repaired = opts.Repair && !report.IsValid()
+
+	return report, nil
+}
+
+func (tableSchema *tableSchemaRedisSearch) loadDocuments(mysql *beeorm.DB, query string, columns, geoFields []string, lastID uint64) []*verifyDocument {
+	results, def := mysql.Query(query, lastID)
+	defer def()
+
+	pointers := make([]interface{}, len(columns)+len(geoFields)*2+1)
+	geoOffset := len(columns) + 1
+	documents := make([]*verifyDocument, 0)
+
+	for results.Next() {
+		id := uint64(0)
+		pointers[0] = &id
+
+		for i, column := range columns {
+			pointers[i+1] = tableSchema.mapBindToScanPointer[column]()
+		}
+
+		for i := range geoFields {
+			pointers[geoOffset+i*2] = &sql.NullFloat64{}
+			pointers[geoOffset+i*2+1] = &sql.NullFloat64{}
+		}
+
+		results.Scan(pointers...)
+
+		document := &verifyDocument{id: id, fields: map[string]interface{}{}}
+
+		for i, column := range columns {
+			if mapped := tableSchema.mapBindToRedisSearch[column](tableSchema.mapPointerToValue[column](pointers[i+1])); mapped != nil {
+				document.fields[column] = mapped
+			}
+		}
+
+		for i, name := range geoFields {
+			if mapped := geoValue(pointers[geoOffset+i*2], pointers[geoOffset+i*2+1]); mapped != nil {
+				document.fields[name] = mapped
+			}
+		}
+
+		documents = append(documents, document)
+	}
+
+	return documents
+}
+
+func (r *RedisSearchEngine) verifyDocuments(report *RedisSearchVerifyReport, prefix string, columns []string, documents []*verifyDocument) error {
+	args := []interface{}{"EVAL", verifyHGetAllScript, len(documents)}
+
+	for _, document := range documents {
+		args = append(args, r.redis.AddNamespacePrefix(prefix+strconv.FormatUint(document.id, 10)))
+	}
+
+	cmd := redis.NewSliceCmd(r.ctx, args...)
+
+	if err := r.process(cmd, "EVAL", "HGETALL"); err != nil {
+		return err
+	}
+
+	hashes, err := cmd.Result()
+	if err != nil {
+		return err
+	}
+
+	for i, document := range documents {
+		report.Checked++
+
+		values, _ := hashes[i].([]interface{})
+		if len(values) == 0 {
+			report.Missing = append(report.Missing, document.id)
+
+			continue
+		}
+
+		hash := make(map[string]string, len(values)/2)
+		for j := 0; j < len(values)-1; j += 2 {
+			hash[parseReplyString(values[j])] = parseReplyString(values[j+1])
+		}
+
+		for _, column := range columns {
+			expected, hasExpected := document.fields[column]
+			actual, hasActual := hash[column]
+
+			if hasExpected != hasActual || (hasExpected && redisSearchValueString(expected) != actual) {
+				report.Stale = append(report.Stale, document.id)
+
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func (tableSchema *tableSchemaRedisSearch) verifyRepair(pusher RedisSearchIndexPusher, report *RedisSearchVerifyReport, documents []*verifyDocument) {
+	broken := map[uint64]bool{}
+
+	for _, id := range report.Missing {
+		broken[id] = true
+	}
+
+	for _, id := range report.Stale {
+		broken[id] = true
+	}
+
+	for _, document := range documents {
+		if !broken[document.id] {
+			continue
+		}
+
+		key := tableSchema.redisSearchPrefix + strconv.FormatUint(document.id, 10)
+
+		pusher.DeleteDocuments(key)
+		pusher.NewDocument(key)
+
+		for _, column := range sortedKeys(document.fields) {
+			pusher.setField(column, document.fields[column])
+		}
+
+		pusher.PushDocument()
+	}
+
+	pusher.Flush()
+}
+
+func (r *RedisSearchEngine) verifyOrphans(
+	report *RedisSearchVerifyReport,
+	tableSchema *tableSchemaRedisSearch,
+	schema beeorm.EntitySchema,
+	prefix string,
+	batchSize int,
+) error {
+	namespacedPrefix := r.redis.AddNamespacePrefix(prefix)
+	cursor := "0"
+
+	for {
+		cmd := redis.NewSliceCmd(r.ctx, "SCAN", cursor, "MATCH", namespacedPrefix+"*", "COUNT", batchSize)
+
+		if err := r.process(cmd, "SCAN", ""); err != nil {
+			return err
+		}
+
+		res, err := cmd.Result()
+		if err != nil {
+			return err
+		}
+
+		if len(res) != 2 {
+			return fmt.Errorf("%w: scan reply with %d elements", ErrUnexpectedReply, len(res))
+		}
+
+		cursor = parseReplyString(res[0])
+		keys, _ := res[1].([]interface{})
+		ids := make([]uint64, 0, len(keys))
+
+		for _, key := range keys {
+			// keys of other blue/green versions share the same prefix
+			id, err := strconv.ParseUint(strings.TrimPrefix(parseReplyString(key), namespacedPrefix), 10, 64)
+			if err == nil {
+				ids = append(ids, id)
+			}
+		}
+
+		if len(ids) > 0 {
+			report.Orphaned = append(report.Orphaned, tableSchema.verifyMissingRows(r.engine, schema, ids)...)
+		}
+
+		if cursor == "0" {
+			return nil
+		}
+	}
+}
+
+func (tableSchema *tableSchemaRedisSearch) verifyMissingRows(engine beeorm.Engine, schema beeorm.EntitySchema, ids []uint64) []uint64 {
+	query := "SELECT `ID` FROM `" + schema.GetTableName() + "` WHERE `ID` IN ("
+
+	for i, id := range ids {
+		if i > 0 {
+			query += ","
+		}
+
+		query += strconv.FormatUint(id, 10)
+	}
+
+	query += ")"
+	if tableSchema.hasFakeDelete && !tableSchema.hasSearchableFakeDelete {
+		query += " AND FakeDelete = 0"
+	}
+
+	results, def := engine.GetMysql(schema.GetMysqlPool()).Query(query)
+	defer def()
+
+	existing := make(map[uint64]bool, len(ids))
+
+	for results.Next() {
+		id := uint64(0)
+		results.Scan(&id)
+		existing[id] = true
+	}
+
+	missing := make([]uint64, 0)
+
+	for _, id := range ids {
+		if !existing[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	return missing
+}
+
+// redisSearchValueString returns value in the form it is stored in redis hash
+func redisSearchValueString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case int:
+		return strconv.Itoa(v)
+	case int8:
+		return strconv.FormatInt(int64(v), 10)
+	case int16:
+		return strconv.FormatInt(int64(v), 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 64)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		if v {
+			return "1"
+		}
+
+		return "0"
+	}
+
+	return ""
+}